@@ -233,6 +233,87 @@ func (m *Manager) detectCompleteCircularDeleteSet(ctx context.Context) CircularD
 	return result
 }
 
+// applyCircularPutFix temporarily replaces affected route_map_clause PUT data with versions having empty match_vrf.
+func (m *Manager) applyCircularPutFix(ctx context.Context, info CircularPutInfo) {
+	tflog.Info(ctx, "Applying circular reference fix for route_map_clause and tenant", map[string]interface{}{
+		"affected_clauses":   info.ClauseNames,
+		"referenced_tenants": info.TenantNames,
+	})
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	routeMapClauseOps := m.resources["route_map_clause"]
+	for name, data := range info.AffectedClauses {
+		routeMapClauseOps.Put[name] = m.createRouteMapClauseWithEmptyMatchVrf(data)
+		tflog.Debug(ctx, fmt.Sprintf("Temporarily setting match_vrf to empty for route_map_clause: %s", name))
+	}
+}
+
+// prepareMatchVrfRestore queues PATCH operations restoring the original match_vrf of the clauses
+// affected by applyCircularPutFix, and puts their original PUT data back. Returns the patched clause names.
+func (m *Manager) prepareMatchVrfRestore(ctx context.Context, info CircularPutInfo) []string {
+	tflog.Info(ctx, "Applying PATCH to restore match_vrf fields in route_map_clause")
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	routeMapClauseOps := m.resources["route_map_clause"]
+	if routeMapClauseOps.Patch == nil {
+		routeMapClauseOps.Patch = make(map[string]interface{})
+	}
+
+	affectedNames := make([]string, 0, len(info.AffectedClauses))
+	for name, originalData := range info.AffectedClauses {
+		patchData := m.createMatchVrfPatchData(originalData, m.getMatchVrfValue(originalData))
+		if patchData != nil {
+			routeMapClauseOps.Patch[name] = patchData
+			affectedNames = append(affectedNames, name)
+			tflog.Debug(ctx, fmt.Sprintf("Prepared PATCH for route_map_clause: %s", name))
+		}
+
+		// Restore original PUT data for future reference
+		routeMapClauseOps.Put[name] = originalData
+	}
+	return affectedNames
+}
+
+// prepareMatchVrfClear queues PATCH operations clearing match_vrf on every clause of a circular
+// set that is about to be deleted.
+func (m *Manager) prepareMatchVrfClear(ctx context.Context, info CircularDeleteInfo) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	routeMapClauseOps := m.resources["route_map_clause"]
+	if routeMapClauseOps.Patch == nil {
+		routeMapClauseOps.Patch = make(map[string]interface{})
+	}
+
+	for _, clauseName := range info.ClauseNames {
+		// Prefer the clause data fetched from the API, fall back to pending PUT data
+		var originalData interface{}
+		if clauseData, exists := info.AffectedClauses[clauseName]; exists {
+			originalData = clauseData
+		} else if putData, exists := routeMapClauseOps.Put[clauseName]; exists {
+			originalData = putData
+		}
+
+		routeMapClauseOps.Patch[clauseName] = m.createMatchVrfPatchData(originalData, "")
+		tflog.Debug(ctx, fmt.Sprintf("Clearing match_vrf for route_map_clause: %s before deletion", clauseName))
+	}
+}
+
+// clearRouteMapClausePatches removes the temporary match_vrf PATCH operations once they have been sent.
+func (m *Manager) clearRouteMapClausePatches(clauseNames []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	routeMapClauseOps := m.resources["route_map_clause"]
+	for _, name := range clauseNames {
+		delete(routeMapClauseOps.Patch, name)
+	}
+}
+
 // ================================================================================================
 // CIRCULAR REFERENCE - HELPER FUNCTIONS
 // ================================================================================================
@@ -0,0 +1,257 @@
+package bulkops
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-verity/internal/utils"
+)
+
+// ================================================================================================
+// DEPENDENCY GRAPH
+// ================================================================================================
+//
+// Execution order is derived from the DependsOn lists in the resource registry:
+// - PUT and PATCH batches for a resource type run after the batches of every type it depends on
+// - DELETE batches run in the reverse order, so referencing objects are removed first
+// - Types are grouped into levels; types within a level do not depend on each other and can be
+//   sent concurrently, otherwise they are sent in alphabetical order to keep runs stable
+// - A dependency cycle is reported as an error instead of being silently broken
+// ================================================================================================
+
+// ExecutionOrder returns the resource types available in the given mode, ordered so that every
+// type comes after the types it depends on. DELETE operations use the reverse of this order.
+func ExecutionOrder(mode string) ([]string, error) {
+	levels, err := ExecutionLevels(mode)
+	if err != nil {
+		return nil, err
+	}
+	return flattenLevels(levels), nil
+}
+
+// ExecutionLevels groups the resource types available in the given mode into dependency levels.
+// Every type depends only on types of earlier levels, so the batches of one level can be sent
+// concurrently.
+func ExecutionLevels(mode string) ([][]string, error) {
+	levels, err := ResolveExecutionLevels(modeDependencies(mode))
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine %s execution order: %w", mode, err)
+	}
+	return levels, nil
+}
+
+// DependentTypes returns the resource types available in the given mode that depend on any of
+// the given types, directly or through other types, sorted alphabetically. Their PUT and PATCH
+// operations may reference objects of the given types.
+func DependentTypes(mode string, resourceTypes []string) []string {
+	dependencies := modeDependencies(mode)
+	dependents := make(map[string][]string, len(dependencies))
+	for resourceType, dependsOn := range dependencies {
+		for _, dependency := range uniqueDependencies(resourceType, dependsOn, dependencies) {
+			dependents[dependency] = append(dependents[dependency], resourceType)
+		}
+	}
+	return reachableTypes(resourceTypes, dependents)
+}
+
+// DependencyTypes returns the resource types available in the given mode that any of the given
+// types depend on, directly or through other types, sorted alphabetically. Their DELETE
+// operations may remove objects that the given types still reference.
+func DependencyTypes(mode string, resourceTypes []string) []string {
+	dependencies := modeDependencies(mode)
+	edges := make(map[string][]string, len(dependencies))
+	for resourceType, dependsOn := range dependencies {
+		edges[resourceType] = uniqueDependencies(resourceType, dependsOn, dependencies)
+	}
+	return reachableTypes(resourceTypes, edges)
+}
+
+// modeDependencies returns the dependency graph of the resource types available in the given mode.
+func modeDependencies(mode string) map[string][]string {
+	dependencies := make(map[string][]string)
+	for resourceType, config := range resourceRegistry {
+		if !isResourceTypeAvailableInMode(resourceType, mode) {
+			continue
+		}
+		dependencies[resourceType] = config.DependsOn
+	}
+	return dependencies
+}
+
+// reachableTypes returns the types reachable from start over at least one edge.
+func reachableTypes(start []string, edges map[string][]string) []string {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	reached := make([]string, 0)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[current] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			reached = append(reached, next)
+			queue = append(queue, next)
+		}
+	}
+
+	sort.Strings(reached)
+	return reached
+}
+
+// ResolveExecutionOrder topologically sorts the given dependency graph, where each key lists the
+// resource types it depends on. Dependencies that are not keys of the graph are ignored, as are
+// self references (objects of the same type are always sent in the same batch).
+func ResolveExecutionOrder(dependencies map[string][]string) ([]string, error) {
+	levels, err := ResolveExecutionLevels(dependencies)
+	if err != nil {
+		return nil, err
+	}
+	return flattenLevels(levels), nil
+}
+
+// ResolveExecutionLevels topologically sorts the given dependency graph into levels. The first
+// level holds the types without dependencies, and each following level holds the types whose
+// dependencies are all in earlier levels. Types within a level are sorted alphabetically.
+func ResolveExecutionLevels(dependencies map[string][]string) ([][]string, error) {
+	inDegree := make(map[string]int, len(dependencies))
+	dependents := make(map[string][]string, len(dependencies))
+
+	for resourceType := range dependencies {
+		inDegree[resourceType] = 0
+	}
+	for resourceType, dependsOn := range dependencies {
+		for _, dependency := range uniqueDependencies(resourceType, dependsOn, dependencies) {
+			inDegree[resourceType]++
+			dependents[dependency] = append(dependents[dependency], resourceType)
+		}
+	}
+
+	ready := make([]string, 0)
+	for resourceType, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, resourceType)
+		}
+	}
+
+	levels := make([][]string, 0)
+	resolved := 0
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		levels = append(levels, ready)
+		resolved += len(ready)
+
+		next := make([]string, 0)
+		for _, resourceType := range ready {
+			for _, dependent := range dependents[resourceType] {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		ready = next
+	}
+
+	if resolved != len(dependencies) {
+		return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(findCycle(dependencies, inDegree), " -> "))
+	}
+	return levels, nil
+}
+
+func flattenLevels(levels [][]string) []string {
+	order := make([]string, 0)
+	for _, level := range levels {
+		order = append(order, level...)
+	}
+	return order
+}
+
+// uniqueDependencies returns the dependencies of resourceType that are part of the graph,
+// without duplicates or self references.
+func uniqueDependencies(resourceType string, dependsOn []string, dependencies map[string][]string) []string {
+	seen := make(map[string]bool, len(dependsOn))
+	result := make([]string, 0, len(dependsOn))
+	for _, dependency := range dependsOn {
+		if dependency == resourceType || seen[dependency] {
+			continue
+		}
+		if _, exists := dependencies[dependency]; !exists {
+			continue
+		}
+		seen[dependency] = true
+		result = append(result, dependency)
+	}
+	return result
+}
+
+// findCycle returns one cycle among the resource types that could not be ordered,
+// starting and ending with the same type.
+func findCycle(dependencies map[string][]string, inDegree map[string]int) []string {
+	remaining := make([]string, 0)
+	for resourceType, degree := range inDegree {
+		if degree > 0 {
+			remaining = append(remaining, resourceType)
+		}
+	}
+	sort.Strings(remaining)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(remaining))
+	path := make([]string, 0, len(remaining))
+
+	var visit func(resourceType string) []string
+	visit = func(resourceType string) []string {
+		state[resourceType] = visiting
+		path = append(path, resourceType)
+
+		dependsOn := uniqueDependencies(resourceType, dependencies[resourceType], dependencies)
+		sort.Strings(dependsOn)
+		for _, dependency := range dependsOn {
+			if inDegree[dependency] == 0 {
+				continue
+			}
+			switch state[dependency] {
+			case visiting:
+				for i, p := range path {
+					if p == dependency {
+						cycle := append([]string{}, path[i:]...)
+						return append(cycle, dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[resourceType] = done
+		return nil
+	}
+
+	for _, resourceType := range remaining {
+		if state[resourceType] == unvisited {
+			if cycle := visit(resourceType); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return remaining
+}
+
+// isResourceTypeAvailableInMode reports whether a bulk resource type exists on the given system mode.
+func isResourceTypeAvailableInMode(resourceType, mode string) bool {
+	terraformType := "verity_" + resourceType
+	if resourceType == "acl" {
+		// Both ACL resources share the "acl" bulk type and are available in the same modes
+		terraformType = "verity_acl_v4"
+	}
+	return utils.IsResourceCompatibleWithMode(terraformType, mode)
+}
@@ -0,0 +1,136 @@
+package bulkops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DryRunBatch is a bulk request that a dry run recorded instead of sending.
+type DryRunBatch struct {
+	Sequence     int               `json:"sequence"`
+	ResourceType string            `json:"resource_type"`
+	Method       string            `json:"method"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Names        []string          `json:"names"`
+	Body         json.RawMessage   `json:"body,omitempty"`
+}
+
+// dryRun records the batches of a dry run in the order they would have been sent.
+type dryRun struct {
+	mu sync.Mutex
+	// path is the file the batches are written to as JSON lines, they are logged when empty
+	path     string
+	sequence int
+}
+
+// SetDryRun makes the manager record every batch it would send instead of sending it, and
+// fail the batch's operations so Terraform stops without changing its state. The batches are
+// written to path, one JSON object per line, or logged when path is empty. As Terraform does
+// not queue the operations of resources whose dependencies failed, a dry run only records the
+// resources that do not depend on other resources changed in the same run.
+func (m *Manager) SetDryRun(enabled bool, path string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !enabled {
+		m.dryRun = nil
+		return
+	}
+	m.dryRun = &dryRun{path: path}
+}
+
+// DryRun reports whether the manager records batches instead of sending them.
+func (m *Manager) DryRun() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.dryRun != nil
+}
+
+func (m *Manager) activeDryRun() *dryRun {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.dryRun
+}
+
+// recordDryRunBatch records a batch that was about to be sent and fails its operations.
+func (m *Manager) recordDryRunBatch(ctx context.Context, run *dryRun, config BulkOperationConfig, resourceNames []string, request interface{}) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	names := append([]string(nil), resourceNames...)
+	sort.Strings(names)
+	batch := DryRunBatch{
+		ResourceType: config.ResourceType,
+		Method:       config.OperationType,
+		Headers:      config.Headers,
+		Names:        names,
+	}
+	// DELETE requests carry the names in the query
+	if config.OperationType != "DELETE" {
+		body, err := json.Marshal(request)
+		if err != nil {
+			diagnostics.AddError(
+				fmt.Sprintf("Failed to record bulk %s %s operation", config.ResourceType, config.OperationType),
+				fmt.Sprintf("Error encoding request body: %s", err),
+			)
+			m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames, err)
+			return diagnostics
+		}
+		batch.Body = body
+	}
+
+	location, err := run.write(ctx, &batch)
+	if err != nil {
+		diagnostics.AddError(
+			fmt.Sprintf("Failed to record bulk %s %s operation", config.ResourceType, config.OperationType),
+			fmt.Sprintf("Error writing dry run file: %s", err),
+		)
+		m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames, err)
+		return diagnostics
+	}
+
+	m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames,
+		fmt.Errorf("dry run: bulk %s %s request %d was recorded in %s instead of being sent", config.ResourceType, config.OperationType, batch.Sequence, location))
+	return diagnostics
+}
+
+// write numbers the batch and writes it out, returning where it was written to. The first
+// batch truncates the file, so it only holds the batches of the current run.
+func (r *dryRun) write(ctx context.Context, batch *DryRunBatch) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sequence++
+	batch.Sequence = r.sequence
+
+	line, err := json.Marshal(batch)
+	if err != nil {
+		return "", err
+	}
+
+	if r.path == "" {
+		tflog.Info(ctx, fmt.Sprintf("[DRY-RUN] Bulk request %d: %s %s", batch.Sequence, batch.Method, batch.ResourceType), map[string]interface{}{
+			"batch": string(line),
+		})
+		return "the provider log", nil
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if batch.Sequence == 1 {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(r.path, flags, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return "", err
+	}
+	return r.path, file.Close()
+}
@@ -0,0 +1,272 @@
+package bulkops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxAttributionRequests bounds the extra requests sent to isolate the resources the API
+// rejected in one batch. Bisecting a batch costs about two requests per level for each bad
+// resource, so a few bad resources are found in any batch while an error that every resource
+// hits does not turn into a request per resource.
+const maxAttributionRequests = 64
+
+// batchAttribution tracks the requests sent for one batch to isolate the resources the API
+// rejected.
+type batchAttribution struct {
+	// requests is the number of requests left for isolating rejected resources
+	requests int
+	// wholeBatchFailed is set when a request failed for a reason other than its resources, such
+	// as a server error or a timeout, which is likely to fail the following batches as well
+	wholeBatchFailed bool
+}
+
+// rejectedBatch reports whether the API rejected the content of a bulk request, which may be
+// down to some of its resources. Authentication, missing endpoints, timeouts, throttling and
+// server errors are not caused by the resources and fail the whole batch.
+func rejectedBatch(resp *http.Response) bool {
+	if resp == nil || resp.StatusCode < 400 || resp.StatusCode >= 500 {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+		http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return true
+}
+
+// attributeBatchFailure finds the resources that made the API reject a batch, so only they
+// fail and the rest of the batch is sent again. Resources named in the error payload fail
+// directly; when the payload names none, the batch is bisected until the rejected resources
+// are isolated or the attribution requests run out.
+func (m *Manager) attributeBatchFailure(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string, opErr error, attribution *batchAttribution) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if culprits := culpritsFromError(opErr, resourceNames); len(culprits) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("API rejected %d of %d %s in bulk %s operation", len(culprits), len(resourceNames), config.ResourceType, config.OperationType),
+			map[string]interface{}{
+				fmt.Sprintf("%s_names", config.ResourceType): culprits,
+			})
+
+		m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, culprits, opErr)
+		diagnostics.AddError(
+			fmt.Sprintf("Failed to execute bulk %s %s operation", config.ResourceType, config.OperationType),
+			fmt.Sprintf("The API rejected %s: %s", strings.Join(culprits, ", "), errorDetail(opErr)),
+		)
+
+		remaining := withoutNames(resourceNames, culprits)
+		if len(remaining) > 0 {
+			attribution.requests--
+			diagnostics.Append(m.sendBatch(ctx, config, subsetOperations(operations, remaining), remaining, attribution)...)
+		}
+		return diagnostics
+	}
+
+	// bisect in name order, so that the same batch is always split the same way
+	sorted := append([]string(nil), resourceNames...)
+	sort.Strings(sorted)
+	middle := len(sorted) / 2
+	tflog.Info(ctx, fmt.Sprintf("Splitting rejected bulk %s %s operation of %d resources to isolate the failing ones",
+		config.ResourceType, config.OperationType, len(resourceNames)))
+
+	for _, half := range [][]string{sorted[:middle], sorted[middle:]} {
+		attribution.requests--
+		diagnostics.Append(m.sendBatch(ctx, config, subsetOperations(operations, half), half, attribution)...)
+	}
+	return diagnostics
+}
+
+// culpritsFromError returns the resources of the batch that the API error payload names. A
+// JSON payload is searched for error messages naming a resource and for errors keyed by or
+// carrying a resource name, so that resources echoed back from the request are not taken for
+// culprits; other payloads are searched for the names as whole words. A payload naming every
+// resource of the batch names none of them.
+func culpritsFromError(opErr error, resourceNames []string) []string {
+	bodyErr, ok := opErr.(interface{ Body() []byte })
+	if !ok {
+		return nil
+	}
+	body := bodyErr.Body()
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+
+	batch := make(map[string]bool, len(resourceNames))
+	for _, name := range resourceNames {
+		batch[name] = true
+	}
+	named := make(map[string]bool)
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		collectCulprits(payload, "", batch, named)
+	} else {
+		for _, name := range resourceNames {
+			if mentionsName(string(body), name) {
+				named[name] = true
+			}
+		}
+	}
+
+	var culprits []string
+	for _, name := range resourceNames {
+		if named[name] {
+			culprits = append(culprits, name)
+		}
+	}
+	if len(culprits) == len(resourceNames) {
+		return nil
+	}
+	return culprits
+}
+
+// errorKeys are the keys under which API error payloads carry their messages.
+var errorKeys = map[string]bool{
+	"payload": true, "error": true, "errors": true, "message": true, "messages": true,
+	"detail": true, "details": true, "reason": true,
+}
+
+// nameKeys are the keys under which an error entry names the resource it is about.
+var nameKeys = []string{"name", "resource", "resource_name", "object", "object_name"}
+
+// collectCulprits adds to named the resources of batch that value, found under key in the
+// error payload, names: in a message, as the key of an error, or in the name of an error entry.
+func collectCulprits(value interface{}, key string, batch, named map[string]bool) {
+	switch v := value.(type) {
+	case string:
+		if !errorKeys[strings.ToLower(key)] {
+			return
+		}
+		for name := range batch {
+			if namedInMessage(v, name) {
+				named[name] = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectCulprits(item, key, batch, named)
+		}
+	case map[string]interface{}:
+		if isErrorEntry(v) {
+			for _, nameKey := range nameKeys {
+				if name, ok := v[nameKey].(string); ok && batch[name] {
+					named[name] = true
+				}
+			}
+		}
+		for k, item := range v {
+			if batch[k] && isErrorValue(item) {
+				// the messages of an error keyed by a resource are searched as error messages
+				named[k] = true
+				collectCulprits(item, "error", batch, named)
+				continue
+			}
+			collectCulprits(item, k, batch, named)
+		}
+	}
+}
+
+// isErrorEntry reports whether an object of the payload carries an error message.
+func isErrorEntry(entry map[string]interface{}) bool {
+	for k := range entry {
+		if errorKeys[strings.ToLower(k)] {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorValue reports whether the value under a resource name is an error rather than the
+// resource echoed back from the request: a message, a list of messages or an error entry.
+func isErrorValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return len(v) > 0
+	case map[string]interface{}:
+		return isErrorEntry(v)
+	}
+	return false
+}
+
+// namedInMessage reports whether an error message names the resource: quoted, or as a whole
+// word when the name could not be mistaken for an ordinary word such as "invalid" or "value".
+func namedInMessage(message, name string) bool {
+	for _, quote := range []string{`"`, "'", "`"} {
+		if strings.Contains(message, quote+name+quote) {
+			return true
+		}
+	}
+	return strings.IndexFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 && mentionsName(message, name)
+}
+
+// mentionsName reports whether text contains name as a whole word, so that "vlan1" is not
+// found in "vlan10".
+func mentionsName(text, name string) bool {
+	if name == "" {
+		return false
+	}
+	for offset := 0; ; {
+		index := strings.Index(text[offset:], name)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(name)
+		if (start == 0 || !isNameChar(text[start-1])) && (end == len(text) || !isNameChar(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
+
+// errorDetail returns the error with the payload of the API response, if any.
+func errorDetail(opErr error) string {
+	if bodyErr, ok := opErr.(interface{ Body() []byte }); ok && len(bodyErr.Body()) > 0 {
+		return fmt.Sprintf("%s: %s", opErr, bodyErr.Body())
+	}
+	return opErr.Error()
+}
+
+func withoutNames(resourceNames, excluded []string) []string {
+	skip := make(map[string]bool, len(excluded))
+	for _, name := range excluded {
+		skip[name] = true
+	}
+	var remaining []string
+	for _, name := range resourceNames {
+		if !skip[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining
+}
+
+func subsetOperations(operations map[string]interface{}, resourceNames []string) map[string]interface{} {
+	subset := make(map[string]interface{}, len(resourceNames))
+	for _, name := range resourceNames {
+		if op, ok := operations[name]; ok {
+			subset[name] = op
+		}
+	}
+	return subset
+}
@@ -3,9 +3,10 @@ package bulkops
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"reflect"
-	"terraform-provider-verity/internal/utils"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -125,10 +126,22 @@ func (m *Manager) executeBulkOperation(ctx context.Context, config BulkOperation
 		return diagnostics
 	}
 
+	// PATCH and DELETE overwrite the current values, read them first so the run can be rolled back
+	if journal := m.activeJournal(); journal != nil && config.OperationType != "PUT" {
+		if err := m.capturePriorValues(ctx, journal, config, resourceNames); err != nil {
+			m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames, err)
+			diagnostics.AddError(
+				fmt.Sprintf("Failed to execute bulk %s %s operation", config.ResourceType, config.OperationType),
+				fmt.Sprintf("Error: %s", err),
+			)
+			return diagnostics
+		}
+	}
+
 	// For DELETE operations with many resources, batch them to avoid URL length limits
 	// DELETE operations use query parameters which can exceed server URL limits (~8KB for Apache)
-	if config.OperationType == "DELETE" && len(resourceNames) > MaxDeleteBatchSize {
-		return m.executeBatchedDeleteOperation(ctx, config, operations, resourceNames)
+	if config.OperationType == "DELETE" && len(resourceNames) > m.settings.MaxDeleteBatchSize {
+		return m.executeBatchedOperation(ctx, config, operations, resourceNames, m.settings.MaxDeleteBatchSize)
 	}
 
 	// For PUT operations, filter out resources that already exist
@@ -165,67 +178,13 @@ func (m *Manager) executeBulkOperation(ctx context.Context, config BulkOperation
 		filteredResourceNames = resourceNames
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Executing bulk %s %s operation", config.ResourceType, config.OperationType),
-		map[string]interface{}{
-			fmt.Sprintf("%s_count", config.ResourceType): len(filteredOperations),
-			fmt.Sprintf("%s_names", config.ResourceType): filteredResourceNames,
-		})
-
-	request := config.PrepareRequest(filteredOperations)
-
-	// Mark all operations as executing - this sets the ExecutionStartTime so that
-	// WaitForOperation can track timeout from when the API call actually starts
-	m.markOperationsAsExecuting(config.ResourceType, config.OperationType, filteredResourceNames)
-
-	retryConfig := utils.DefaultRetryConfig()
-	var opErr error
-	var apiResp *http.Response
-
-	for retry := 0; retry < retryConfig.MaxRetries; retry++ {
-		if retry > 0 {
-			delayTime := utils.CalculateBackoff(retry, retryConfig)
-			tflog.Debug(ctx, fmt.Sprintf("Retrying bulk %s %s operation after %v",
-				config.ResourceType, config.OperationType, delayTime))
-			time.Sleep(delayTime)
-		}
-
-		apiCtx, cancel := context.WithTimeout(context.Background(), OperationTimeout)
-		apiResp, opErr = config.ExecuteRequest(apiCtx, request)
-		cancel()
-
-		if opErr == nil {
-			break
-		}
-
-		if !utils.IsRetriableError(opErr) {
-			break
-		}
-
-		delayTime := utils.CalculateBackoff(retry, retryConfig)
-		tflog.Debug(ctx, fmt.Sprintf("Bulk %s %s operation failed with retriable error, retrying",
-			config.ResourceType, config.OperationType),
-			map[string]interface{}{
-				"attempt":     retry + 1,
-				"error":       opErr.Error(),
-				"delay_ms":    delayTime.Milliseconds(),
-				"max_retries": retryConfig.MaxRetries,
-			})
-	}
-
-	if opErr == nil && apiResp != nil && config.ProcessResponse != nil {
-		if processErr := config.ProcessResponse(ctx, apiResp); processErr != nil {
-			tflog.Warn(ctx, fmt.Sprintf("Post-processing failed for bulk %s %s operation: %v",
-				config.ResourceType, config.OperationType, processErr))
-		}
+	// PUT and PATCH bodies carry every resource, split large ones to keep requests bounded
+	if config.OperationType != "DELETE" && len(filteredResourceNames) > m.settings.MaxBatchSize {
+		return m.executeBatchedOperation(ctx, config, filteredOperations, filteredResourceNames, m.settings.MaxBatchSize)
 	}
 
-	m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, filteredResourceNames, opErr)
-
-	if opErr != nil {
-		diagnostics.AddError(
-			fmt.Sprintf("Failed to execute bulk %s %s operation", config.ResourceType, config.OperationType),
-			fmt.Sprintf("Error: %s", opErr),
-		)
+	diagnostics = m.executeSingleBatch(ctx, config, filteredOperations, filteredResourceNames)
+	if diagnostics.HasError() {
 		return diagnostics
 	}
 
@@ -233,21 +192,24 @@ func (m *Manager) executeBulkOperation(ctx context.Context, config BulkOperation
 	return diagnostics
 }
 
-// executeBatchedDeleteOperation handles DELETE operations that exceed MaxDeleteBatchSize
-// by splitting them into smaller batches to avoid URL length limits.
-func (m *Manager) executeBatchedDeleteOperation(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string) diag.Diagnostics {
+// executeBatchedOperation handles operations with more resources than batchSize by splitting
+// them into smaller batches: DELETEs to avoid URL length limits, PUTs and PATCHes to bound the
+// request body. A batch the API rejected for some of its resources does not stop the remaining
+// ones. Any other failed batch does, unless ContinueOnError is set, and the operations of the
+// batches that are not sent fail.
+func (m *Manager) executeBatchedOperation(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string, batchSize int) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
 	totalResources := len(resourceNames)
-	batchCount := (totalResources + MaxDeleteBatchSize - 1) / MaxDeleteBatchSize
+	batchCount := (totalResources + batchSize - 1) / batchSize
 
-	tflog.Info(ctx, fmt.Sprintf("Splitting bulk %s DELETE into %d batches of max %d resources each (total: %d)",
-		config.ResourceType, batchCount, MaxDeleteBatchSize, totalResources))
+	tflog.Info(ctx, fmt.Sprintf("Splitting bulk %s %s into %d batches of max %d resources each (total: %d)",
+		config.ResourceType, config.OperationType, batchCount, batchSize, totalResources))
 
 	// Process each batch
 	for batchNum := 0; batchNum < batchCount; batchNum++ {
-		start := batchNum * MaxDeleteBatchSize
-		end := start + MaxDeleteBatchSize
+		start := batchNum * batchSize
+		end := start + batchSize
 		if end > totalResources {
 			end = totalResources
 		}
@@ -260,7 +222,7 @@ func (m *Manager) executeBatchedDeleteOperation(ctx context.Context, config Bulk
 			}
 		}
 
-		tflog.Debug(ctx, fmt.Sprintf("Executing DELETE batch %d/%d for %s", batchNum+1, batchCount, config.ResourceType),
+		tflog.Debug(ctx, fmt.Sprintf("Executing %s batch %d/%d for %s", config.OperationType, batchNum+1, batchCount, config.ResourceType),
 			map[string]interface{}{
 				"batch_size":     len(batchNames),
 				"resource_names": batchNames,
@@ -278,16 +240,25 @@ func (m *Manager) executeBatchedDeleteOperation(ctx context.Context, config Bulk
 			ExecuteRequest:    config.ExecuteRequest,
 			ProcessResponse:   config.ProcessResponse,
 			UpdateRecentOps:   func() {}, // Don't update until all batches complete
+			Headers:           config.Headers,
 		}
 
-		// Execute this batch using the standard execution path (won't recurse since batch size <= MaxDeleteBatchSize)
-		batchDiags := m.executeSingleDeleteBatch(ctx, batchConfig, batchOperations, batchNames)
+		// Execute this batch directly, pre-existence of PUT resources was already checked
+		attribution := &batchAttribution{requests: maxAttributionRequests}
+		batchDiags := m.sendBatch(ctx, batchConfig, batchOperations, batchNames, attribution)
 		diagnostics.Append(batchDiags...)
 
 		if batchDiags.HasError() {
-			tflog.Error(ctx, fmt.Sprintf("DELETE batch %d/%d failed for %s, stopping further batches",
-				batchNum+1, batchCount, config.ResourceType))
-			return diagnostics
+			if attribution.wholeBatchFailed && !m.settings.ContinueOnError {
+				tflog.Error(ctx, fmt.Sprintf("%s batch %d/%d failed for %s, stopping further batches",
+					config.OperationType, batchNum+1, batchCount, config.ResourceType))
+				// the operations were already taken off the queue, fail them so nothing waits on them
+				m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames[end:],
+					fmt.Errorf("not sent after %s batch %d/%d failed", config.OperationType, batchNum+1, batchCount))
+				return diagnostics
+			}
+			tflog.Warn(ctx, fmt.Sprintf("%s batch %d/%d failed for %s, continuing with further batches",
+				config.OperationType, batchNum+1, batchCount, config.ResourceType))
 		}
 
 		// Small delay between batches to avoid overwhelming the server
@@ -296,15 +267,25 @@ func (m *Manager) executeBatchedDeleteOperation(ctx context.Context, config Bulk
 		}
 	}
 
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
 	// Update recent ops after all batches complete successfully
 	config.UpdateRecentOps()
 
-	tflog.Info(ctx, fmt.Sprintf("Successfully completed all %d DELETE batches for %s", batchCount, config.ResourceType))
+	tflog.Info(ctx, fmt.Sprintf("Successfully completed all %d %s batches for %s", batchCount, config.OperationType, config.ResourceType))
 	return diagnostics
 }
 
-// executeSingleDeleteBatch executes a single batch of DELETE operations
-func (m *Manager) executeSingleDeleteBatch(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string) diag.Diagnostics {
+// executeSingleBatch executes a single batch of operations
+func (m *Manager) executeSingleBatch(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string) diag.Diagnostics {
+	return m.sendBatch(ctx, config, operations, resourceNames, &batchAttribution{requests: maxAttributionRequests})
+}
+
+// sendBatch sends one request for the batch. attribution is shared by all parts of the batch
+// sent to isolate the resources the API rejected.
+func (m *Manager) sendBatch(ctx context.Context, config BulkOperationConfig, operations map[string]interface{}, resourceNames []string, attribution *batchAttribution) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
 	tflog.Debug(ctx, fmt.Sprintf("Executing bulk %s %s operation", config.ResourceType, config.OperationType),
@@ -315,43 +296,30 @@ func (m *Manager) executeSingleDeleteBatch(ctx context.Context, config BulkOpera
 
 	request := config.PrepareRequest(operations)
 
+	if run := m.activeDryRun(); run != nil {
+		return m.recordDryRunBatch(ctx, run, config, resourceNames, request)
+	}
+
 	// Mark all operations as executing - this sets the ExecutionStartTime so that
 	// WaitForOperation can track timeout from when the API call actually starts
 	m.markOperationsAsExecuting(config.ResourceType, config.OperationType, resourceNames)
 
-	retryConfig := utils.DefaultRetryConfig()
-	var opErr error
-	var apiResp *http.Response
-
-	for retry := 0; retry < retryConfig.MaxRetries; retry++ {
-		if retry > 0 {
-			delayTime := utils.CalculateBackoff(retry, retryConfig)
-			tflog.Debug(ctx, fmt.Sprintf("Retrying bulk %s %s operation after %v",
-				config.ResourceType, config.OperationType, delayTime))
-			time.Sleep(delayTime)
-		}
-
-		apiCtx, cancel := context.WithTimeout(context.Background(), OperationTimeout)
-		apiResp, opErr = config.ExecuteRequest(apiCtx, request)
-		cancel()
-
-		if opErr == nil {
-			break
-		}
+	// the journal outlives the process, so a batch cut short by a crash can be checked later
+	opJournal := m.activeOperationJournal()
+	var journalID string
+	if opJournal != nil {
+		journalID = opJournal.recordIntent(ctx, config, resourceNames)
+	}
 
-		if !utils.IsRetriableError(opErr) {
-			break
-		}
+	// retriable failures are already retried by the API client's transport
+	m.setRequestEndTime(config.ResourceType, config.OperationType, resourceNames, time.Time{})
+	apiCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	apiResp, opErr := config.ExecuteRequest(apiCtx, request)
+	cancel()
+	m.setRequestEndTime(config.ResourceType, config.OperationType, resourceNames, time.Now())
 
-		delayTime := utils.CalculateBackoff(retry, retryConfig)
-		tflog.Debug(ctx, fmt.Sprintf("Bulk %s %s operation failed with retriable error, retrying",
-			config.ResourceType, config.OperationType),
-			map[string]interface{}{
-				"attempt":     retry + 1,
-				"error":       opErr.Error(),
-				"delay_ms":    delayTime.Milliseconds(),
-				"max_retries": retryConfig.MaxRetries,
-			})
+	if opJournal != nil {
+		opJournal.recordOutcome(ctx, journalID, opErr)
 	}
 
 	if opErr == nil && apiResp != nil && config.ProcessResponse != nil {
@@ -361,13 +329,22 @@ func (m *Manager) executeSingleDeleteBatch(ctx context.Context, config BulkOpera
 		}
 	}
 
-	m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames, opErr)
+	// a rejected batch may be down to a few of its resources, find them so the rest can succeed
+	if opErr != nil && !rejectedBatch(apiResp) {
+		attribution.wholeBatchFailed = true
+	} else if opErr != nil && len(resourceNames) > 1 && attribution.requests > 0 {
+		return m.attributeBatchFailure(ctx, config, operations, resourceNames, opErr, attribution)
+	}
+
+	operationIDs := m.updateOperationStatuses(ctx, config.ResourceType, config.OperationType, resourceNames, opErr)
 
 	if opErr != nil {
 		diagnostics.AddError(
 			fmt.Sprintf("Failed to execute bulk %s %s operation", config.ResourceType, config.OperationType),
 			fmt.Sprintf("Error: %s", opErr),
 		)
+	} else if journal := m.activeJournal(); journal != nil {
+		journal.record(config, resourceNames, operationIDs)
 	}
 
 	return diagnostics
@@ -411,10 +388,12 @@ func (m *Manager) WaitForOperation(ctx context.Context, operationID string, time
 			return nil
 
 		case <-ticker.C:
-			// Check if operation has started executing and if timeout has elapsed
+			// Check if the operation's request is in flight and if timeout has elapsed. Once the
+			// request returned, processing the response and waiting for the rest of the run, as
+			// operations held by the rollback journal do, does not count.
 			m.operationMutex.Lock()
 			op, opExists := m.pendingOperations[operationID]
-			if opExists && op != nil && !op.ExecutionStartTime.IsZero() {
+			if opExists && op != nil && !op.ExecutionStartTime.IsZero() && op.RequestEndTime.IsZero() {
 				// Operation has started executing - check if timeout elapsed from start time
 				elapsed := time.Since(op.ExecutionStartTime)
 				if elapsed >= timeout {
@@ -431,10 +410,13 @@ func (m *Manager) WaitForOperation(ctx context.Context, operationID string, time
 }
 
 // updateOperationStatuses updates the status of pending operations based on the bulk operation result
-func (m *Manager) updateOperationStatuses(ctx context.Context, resourceType, operationType string, resourceNames []string, opErr error) {
+// and returns their IDs. While a rollback journal is active, successful operations only complete
+// when the run is over.
+func (m *Manager) updateOperationStatuses(ctx context.Context, resourceType, operationType string, resourceNames []string, opErr error) []string {
 	var idsToClose []string
 
 	m.operationMutex.Lock()
+	holdCompletion := opErr == nil && m.journal != nil
 
 	resourceMap := make(map[string]bool)
 	for _, name := range resourceNames {
@@ -479,9 +461,13 @@ func (m *Manager) updateOperationStatuses(ctx context.Context, resourceType, ope
 
 	m.operationMutex.Unlock()
 
+	if holdCompletion {
+		return idsToClose
+	}
 	for _, opID := range idsToClose {
 		m.safeCloseChannel(opID, false)
 	}
+	return idsToClose
 }
 
 // markOperationsAsExecuting sets the ExecutionStartTime for all operations in the batch.
@@ -515,6 +501,32 @@ func (m *Manager) markOperationsAsExecuting(resourceType, operationType string,
 	}
 }
 
+// setRequestEndTime sets the RequestEndTime of the executing operations in the batch, zero
+// while a request for them is in flight.
+func (m *Manager) setRequestEndTime(resourceType, operationType string, resourceNames []string, endTime time.Time) {
+	m.operationMutex.Lock()
+	defer m.operationMutex.Unlock()
+
+	resourceMap := make(map[string]bool)
+	for _, name := range resourceNames {
+		resourceMap[name] = true
+	}
+
+	for opID, op := range m.pendingOperations {
+		matchesResourceType := false
+		if resourceType == "acl_v4" || resourceType == "acl_v6" {
+			matchesResourceType = op.ResourceType == "acl"
+		} else {
+			matchesResourceType = op.ResourceType == resourceType
+		}
+
+		if matchesResourceType && op.OperationType == operationType && op.Status == OperationExecuting && resourceMap[op.ResourceName] {
+			op.RequestEndTime = endTime
+			m.pendingOperations[opID] = op
+		}
+	}
+}
+
 // ================================================================================================
 // BULK OPERATION EXECUTION ORCHESTRATION
 // ================================================================================================
@@ -526,7 +538,7 @@ func (m *Manager) WaitAndFlushAllOperations(ctx context.Context) diag.Diagnostic
 	var allDiags diag.Diagnostics
 
 	// Phase 1: Wait for operations to arrive from concurrent sibling resources.
-	initialWaitDeadline := MaxBatchDelay
+	initialWaitDeadline := m.settings.MaxBatchDelay
 	checkInterval := 500 * time.Millisecond
 	elapsed := time.Duration(0)
 
@@ -561,7 +573,7 @@ func (m *Manager) WaitAndFlushAllOperations(ctx context.Context) diag.Diagnostic
 		}
 
 		// Wait to let concurrent resources queue more operations
-		time.Sleep(BatchCollectionWindow)
+		time.Sleep(m.settings.BatchCollectionWindow)
 
 		if m.hasPendingOperations() {
 			consecutiveQuiet = 0
@@ -593,8 +605,8 @@ func (m *Manager) ExecuteAllPendingOperations(ctx context.Context) diag.Diagnost
 	for pass := 0; pass < maxPasses; pass++ {
 		tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] ExecuteAllPendingOperations pass %d — checking for queued operations", pass))
 
-		if time.Since(m.lastOperationTime) < BatchCollectionWindow {
-			remaining := BatchCollectionWindow - time.Since(m.lastOperationTime)
+		if time.Since(m.lastOperationTime) < m.settings.BatchCollectionWindow {
+			remaining := m.settings.BatchCollectionWindow - time.Since(m.lastOperationTime)
 			tflog.Debug(ctx, fmt.Sprintf("Pass %d: Waiting %v to collect more operations before executing", pass, remaining))
 			time.Sleep(remaining)
 		}
@@ -631,7 +643,7 @@ func (m *Manager) ExecuteAllPendingOperations(ctx context.Context) diag.Diagnost
 		}
 
 		// Wait briefly to let in-flight Terraform goroutines submit new operations
-		time.Sleep(BatchCollectionWindow)
+		time.Sleep(m.settings.BatchCollectionWindow)
 
 		// Check if more operations arrived during execution
 		if !m.hasPendingOperations() {
@@ -683,6 +695,7 @@ func (m *Manager) ExecuteAllPendingOperations(ctx context.Context) diag.Diagnost
 			m.clearCacheFunc(ctx, m.contextProvider(), "route_maps")
 			m.clearCacheFunc(ctx, m.contextProvider(), "sfp_breakouts")
 			m.clearCacheFunc(ctx, m.contextProvider(), "sites")
+			m.clearCacheFunc(ctx, m.contextProvider(), "image_update_sets")
 			m.clearCacheFunc(ctx, m.contextProvider(), "pods")
 			m.clearCacheFunc(ctx, m.contextProvider(), "port_acls")
 			m.clearCacheFunc(ctx, m.contextProvider(), "pb_routing")
@@ -697,398 +710,153 @@ func (m *Manager) ExecuteAllPendingOperations(ctx context.Context) diag.Diagnost
 	return diagnostics
 }
 
+// ExecuteDatacenterOperations executes all pending operations for a datacenter system,
+// in the order computed from the resource dependency graph.
 func (m *Manager) ExecuteDatacenterOperations(ctx context.Context) (diag.Diagnostics, bool) {
+	return m.executeWithRollback(ctx, "datacenter")
+}
+
+// ExecuteCampusOperations executes all pending operations for a campus system,
+// in the order computed from the resource dependency graph.
+func (m *Manager) ExecuteCampusOperations(ctx context.Context) (diag.Diagnostics, bool) {
+	return m.executeWithRollback(ctx, "campus")
+}
+
+// executeDependencyLevels runs all PUT operations level by level in dependency order,
+// then all PATCH operations in the same order, then all DELETE operations in reverse order.
+// Batches within a level are independent and are sent concurrently up to MaxParallelBatches.
+// The first failing batch aborts every remaining operation, unless ContinueOnError is set: then
+// only the operations that depend on the failed batch are cancelled and the others still run.
+func (m *Manager) executeDependencyLevels(ctx context.Context, mode string) (diag.Diagnostics, bool) {
 	var diagnostics diag.Diagnostics
 	operationsPerformed := false
 
-	execute := func(opType string, count int, execFunc func(context.Context) diag.Diagnostics, resourceName string) bool {
-		if count > 0 {
-			tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] >>> Proceeding with %s %s for %d resource(s) — sending API request...", resourceName, opType, count))
-			diags := execFunc(ctx)
-			diagnostics.Append(diags...)
-			if diags.HasError() {
-				tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] <<< FAILED %s %s — aborting remaining operations", resourceName, opType))
-				err := fmt.Errorf("bulk %s %s operation failed", resourceName, opType)
-				m.FailAllPendingOperations(ctx, err)
-				return false
-			}
-			tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] <<< Completed %s %s for %d resource(s) — success, moving to next type", resourceName, opType, count))
-			operationsPerformed = true
-		}
-		return true
-	}
-
-	// PUT operations - DC Order (note: sfp_breakout and site are skipped - they only support GET and PATCH)
-	// 2. ipv6_prefix_list
-	if !execute("PUT", m.getOperationCount("ipv6_prefix_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_prefix_list", "PUT") }, "IPv6 Prefix List") {
-		return diagnostics, operationsPerformed
-	}
-	// 3. community_list
-	if !execute("PUT", m.getOperationCount("community_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "community_list", "PUT") }, "Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 4. ipv4_prefix_list
-	if !execute("PUT", m.getOperationCount("ipv4_prefix_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_prefix_list", "PUT") }, "IPv4 Prefix List") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. extended_community_list
-	if !execute("PUT", m.getOperationCount("extended_community_list", "PUT"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "extended_community_list", "PUT")
-	}, "Extended Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. as_path_access_list
-	if !execute("PUT", m.getOperationCount("as_path_access_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "as_path_access_list", "PUT") }, "AS Path Access List") {
+	levels, err := ExecutionLevels(mode)
+	if err != nil {
+		tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] %v — aborting all pending operations", err))
+		diagnostics.AddError("Invalid resource dependency graph", err.Error())
+		m.FailAllPendingOperations(ctx, err)
 		return diagnostics, operationsPerformed
 	}
 
-	// BEFORE executing route_map_clause PUT, check for circular reference scenario
-	circularPutInfo := m.detectCircularReferenceScenario(ctx)
+	tflog.Debug(ctx, fmt.Sprintf("[BULK-OPS] %s execution levels: %v", mode, levels))
 
-	if circularPutInfo.NeedsFix {
-		tflog.Info(ctx, "Applying circular reference fix for route_map_clause and tenant", map[string]interface{}{
-			"affected_clauses":   circularPutInfo.ClauseNames,
-			"referenced_tenants": circularPutInfo.TenantNames,
-		})
+	maxParallel := m.settings.MaxParallelBatches
+	continueOnError := m.settings.ContinueOnError
+
+	// cancelledBy maps the resource types whose operations are cancelled in continue mode to the
+	// failed batch they depend on. It only changes between levels.
+	cancelledBy := make(map[string]string)
 
-		// Temporarily replace affected route_map_clause PUT data with versions having empty match_vrf
-		m.mutex.Lock()
-		routeMapClauseOps := m.resources["route_map_clause"]
-		for name, data := range circularPutInfo.AffectedClauses {
-			modifiedData := m.createRouteMapClauseWithEmptyMatchVrf(data)
-			routeMapClauseOps.Put[name] = modifiedData
-			tflog.Debug(ctx, fmt.Sprintf("Temporarily setting match_vrf to empty for route_map_clause: %s", name))
+	var resultMutex sync.Mutex
+	execute := func(resourceType, opType, label string) bool {
+		count := m.getOperationCount(resourceType, opType)
+		if count == 0 {
+			return true
 		}
-		m.mutex.Unlock()
-	}
 
-	// 7. route_map_clause (PUT with empty match_vrf if circular ref detected)
-	if !execute("PUT", m.getOperationCount("route_map_clause", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map_clause", "PUT") }, "Route Map Clause") {
-		return diagnostics, operationsPerformed
-	}
-	// 8-9. acl (both ipv6 and ipv4)
-	if !execute("PUT", m.getOperationCount("acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "PUT") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. route_map
-	if !execute("PUT", m.getOperationCount("route_map", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map", "PUT") }, "Route Map") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. pb_routing_acl
-	if !execute("PUT", m.getOperationCount("pb_routing_acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "PUT") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. tenant
-	if !execute("PUT", m.getOperationCount("tenant", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "tenant", "PUT") }, "Tenant") {
-		return diagnostics, operationsPerformed
+		if cause, cancelled := cancelledBy[resourceType]; cancelled {
+			tflog.Warn(ctx, fmt.Sprintf("[BULK-OPS] Skipping %s %s for %d resource(s) — %s failed", label, opType, count, cause))
+			m.cancelOperations(ctx, resourceType, opType, fmt.Errorf("bulk %s operation failed", cause))
+			return true
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] >>> Proceeding with %s %s for %d resource(s) — sending API request...", label, opType, count))
+		diags := m.ExecuteBulk(ctx, resourceType, opType)
+
+		resultMutex.Lock()
+		defer resultMutex.Unlock()
+		diagnostics.Append(diags...)
+		if diags.HasError() {
+			if continueOnError {
+				tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] <<< FAILED %s %s — cancelling dependent operations", label, opType))
+			} else {
+				tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] <<< FAILED %s %s — aborting remaining operations", label, opType))
+			}
+			return false
+		}
+		tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] <<< Completed %s %s for %d resource(s) — success, moving to next type", label, opType, count))
+		operationsPerformed = true
+		return true
 	}
 
-	// If circular reference fix was applied, now PATCH route_map_clause with match_vrf
-	if circularPutInfo.NeedsFix && len(circularPutInfo.AffectedClauses) > 0 {
-		tflog.Info(ctx, "Applying PATCH to restore match_vrf fields in route_map_clause")
+	abort := func(failed []string, opType string) {
+		err := fmt.Errorf("bulk %s %s operation failed", strings.Join(failed, ", "), opType)
+		m.FailAllPendingOperations(ctx, err)
+	}
 
-		m.mutex.Lock()
-		// Add PATCH operations for the affected route_map_clauses
-		routeMapClauseOps := m.resources["route_map_clause"]
-		if routeMapClauseOps.Patch == nil {
-			routeMapClauseOps.Patch = make(map[string]interface{})
+	// handleFailure reacts to the failed batches of a level and reports whether execution stops.
+	// In continue mode, PUTs and PATCHes of the types referencing a failed type are cancelled, as
+	// they may reference its missing objects; after a failed DELETE, the DELETEs of the types it
+	// references are cancelled, as their objects are still in use.
+	handleFailure := func(failed []string, opType string) bool {
+		if !continueOnError {
+			abort(failed, opType)
+			return true
 		}
 
-		affectedNames := make([]string, 0, len(circularPutInfo.AffectedClauses))
-		for name, originalData := range circularPutInfo.AffectedClauses {
-			// Extract the original match_vrf value to restore
-			matchVrfValue := m.getMatchVrfValue(originalData)
-
-			// Create PATCH data to restore the match_vrf
-			patchData := m.createMatchVrfPatchData(originalData, matchVrfValue)
-			if patchData != nil {
-				routeMapClauseOps.Patch[name] = patchData
-				affectedNames = append(affectedNames, name)
-				tflog.Debug(ctx, fmt.Sprintf("Prepared PATCH for route_map_clause: %s", name))
+		cause := fmt.Sprintf("%s %s", strings.Join(failed, ", "), opType)
+		affected := DependentTypes(mode, failed)
+		if opType == "DELETE" {
+			affected = DependencyTypes(mode, failed)
+		}
+		for _, resourceType := range affected {
+			if _, cancelled := cancelledBy[resourceType]; !cancelled {
+				cancelledBy[resourceType] = cause
 			}
+		}
+		return false
+	}
 
-			// Restore original PUT data for future reference
-			routeMapClauseOps.Put[name] = originalData
+	// executeSingle runs one extra batch outside of the dependency levels. These batches fix
+	// up circular references between route map clauses and tenants, so a failure always aborts.
+	executeSingle := func(resourceType, opType, label string) bool {
+		if !execute(resourceType, opType, label) {
+			abort([]string{label}, opType)
+			return false
 		}
-		m.mutex.Unlock()
+		return true
+	}
 
-		tflog.Info(ctx, "Executing PATCH to restore match_vrf", map[string]interface{}{
-			"affected_resources": affectedNames,
-		})
-		if !execute("PATCH", m.getOperationCount("route_map_clause", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-			return m.ExecuteBulk(ctx, "route_map_clause", "PATCH")
-		}, "Route Map Clause (match_vrf restore)") {
-			return diagnostics, operationsPerformed
+	// PUT operations (sfp_breakout, site and image_update_set are skipped - they only support GET and PATCH)
+	var circularPutInfo CircularPutInfo
+	for _, level := range levels {
+		if contains(level, "route_map_clause") {
+			// BEFORE executing route_map_clause PUT, check for circular reference scenario
+			circularPutInfo = m.detectCircularReferenceScenario(ctx)
+			if circularPutInfo.NeedsFix {
+				m.applyCircularPutFix(ctx, circularPutInfo)
+			}
 		}
 
-		// Clean up the PATCH operations
-		m.mutex.Lock()
-		for name := range circularPutInfo.AffectedClauses {
-			delete(routeMapClauseOps.Patch, name)
+		if failed := m.executeLevel(level, "PUT", maxParallel, !continueOnError, execute); len(failed) > 0 && handleFailure(failed, "PUT") {
+			return diagnostics, operationsPerformed
 		}
-		m.mutex.Unlock()
 
-		tflog.Info(ctx, "Successfully restored match_vrf fields via PATCH")
-	}
+		// If circular reference fix was applied, now PATCH route_map_clause with match_vrf
+		if contains(level, "tenant") && circularPutInfo.NeedsFix && len(circularPutInfo.AffectedClauses) > 0 {
+			affectedNames := m.prepareMatchVrfRestore(ctx, circularPutInfo)
 
-	// 13. pb_routing
-	if !execute("PUT", m.getOperationCount("pb_routing", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "PUT") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. ipv4_list
-	if !execute("PUT", m.getOperationCount("ipv4_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "PUT") }, "IPv4 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. ipv6_list
-	if !execute("PUT", m.getOperationCount("ipv6_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "PUT") }, "IPv6 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. service
-	if !execute("PUT", m.getOperationCount("service", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "PUT") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. port_acl
-	if !execute("PUT", m.getOperationCount("port_acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "PUT") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. packet_broker
-	if !execute("PUT", m.getOperationCount("packet_broker", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_broker", "PUT") }, "Packet Broker") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. eth_port_profile
-	if !execute("PUT", m.getOperationCount("eth_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "PUT") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. packet_queue
-	if !execute("PUT", m.getOperationCount("packet_queue", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "PUT") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. sflow_collector
-	if !execute("PUT", m.getOperationCount("sflow_collector", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "PUT") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. gateway
-	if !execute("PUT", m.getOperationCount("gateway", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway", "PUT") }, "Gateway") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. lag
-	if !execute("PUT", m.getOperationCount("lag", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "PUT") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. eth_port_settings
-	if !execute("PUT", m.getOperationCount("eth_port_settings", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "PUT") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. diagnostics_profile
-	if !execute("PUT", m.getOperationCount("diagnostics_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "PUT") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. gateway_profile
-	if !execute("PUT", m.getOperationCount("gateway_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway_profile", "PUT") }, "Gateway Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 27. device_settings
-	if !execute("PUT", m.getOperationCount("device_settings", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "PUT") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 28. diagnostics_port_profile
-	if !execute("PUT", m.getOperationCount("diagnostics_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "PUT")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 29. bundle
-	if !execute("PUT", m.getOperationCount("bundle", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "PUT") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 30. pod
-	if !execute("PUT", m.getOperationCount("pod", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pod", "PUT") }, "Pod") {
-		return diagnostics, operationsPerformed
-	}
-	// 31. badge
-	if !execute("PUT", m.getOperationCount("badge", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "PUT") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 32. spine_plane
-	if !execute("PUT", m.getOperationCount("spine_plane", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "spine_plane", "PUT") }, "Spine Plane") {
-		return diagnostics, operationsPerformed
-	}
-	// 33. switchpoint
-	if !execute("PUT", m.getOperationCount("switchpoint", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "PUT") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 34. threshold
-	if !execute("PUT", m.getOperationCount("threshold", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "PUT") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 35. grouping_rule
-	if !execute("PUT", m.getOperationCount("grouping_rule", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "PUT") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 36. threshold_group
-	if !execute("PUT", m.getOperationCount("threshold_group", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "PUT") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 38. device_controller
-	if !execute("PUT", m.getOperationCount("device_controller", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "PUT") }, "Device Controller") {
-		return diagnostics, operationsPerformed
-	}
+			tflog.Info(ctx, "Executing PATCH to restore match_vrf", map[string]interface{}{
+				"affected_resources": affectedNames,
+			})
+			if !executeSingle("route_map_clause", "PATCH", "route_map_clause (match_vrf restore)") {
+				return diagnostics, operationsPerformed
+			}
+			m.clearRouteMapClausePatches(circularPutInfo.ClauseNames)
 
-	// PATCH operations - DC Order
-	// 1. sfp_breakout
-	if !execute("PATCH", m.getOperationCount("sfp_breakout", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sfp_breakout", "PATCH") }, "SFP Breakout") {
-		return diagnostics, operationsPerformed
-	}
-	// 2. ipv6_prefix_list
-	if !execute("PATCH", m.getOperationCount("ipv6_prefix_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_prefix_list", "PATCH") }, "IPv6 Prefix List") {
-		return diagnostics, operationsPerformed
-	}
-	// 3. community_list
-	if !execute("PATCH", m.getOperationCount("community_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "community_list", "PATCH") }, "Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 4. ipv4_prefix_list
-	if !execute("PATCH", m.getOperationCount("ipv4_prefix_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_prefix_list", "PATCH") }, "IPv4 Prefix List") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. extended_community_list
-	if !execute("PATCH", m.getOperationCount("extended_community_list", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "extended_community_list", "PATCH")
-	}, "Extended Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. as_path_access_list
-	if !execute("PATCH", m.getOperationCount("as_path_access_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "as_path_access_list", "PATCH") }, "AS Path Access List") {
-		return diagnostics, operationsPerformed
-	}
-	// 7. route_map_clause
-	if !execute("PATCH", m.getOperationCount("route_map_clause", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map_clause", "PATCH") }, "Route Map Clause") {
-		return diagnostics, operationsPerformed
-	}
-	// 8-9. acl (both ipv6 and ipv4)
-	if !execute("PATCH", m.getOperationCount("acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "PATCH") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. route_map
-	if !execute("PATCH", m.getOperationCount("route_map", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map", "PATCH") }, "Route Map") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. pb_routing_acl
-	if !execute("PATCH", m.getOperationCount("pb_routing_acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "PATCH") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. tenant
-	if !execute("PATCH", m.getOperationCount("tenant", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "tenant", "PATCH") }, "Tenant") {
-		return diagnostics, operationsPerformed
-	}
-	// 13. pb_routing
-	if !execute("PATCH", m.getOperationCount("pb_routing", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "PATCH") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. ipv4_list
-	if !execute("PATCH", m.getOperationCount("ipv4_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "PATCH") }, "IPv4 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. ipv6_list
-	if !execute("PATCH", m.getOperationCount("ipv6_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "PATCH") }, "IPv6 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. service
-	if !execute("PATCH", m.getOperationCount("service", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "PATCH") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. port_acl
-	if !execute("PATCH", m.getOperationCount("port_acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "PATCH") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. packet_broker
-	if !execute("PATCH", m.getOperationCount("packet_broker", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_broker", "PATCH") }, "Packet Broker") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. eth_port_profile
-	if !execute("PATCH", m.getOperationCount("eth_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "PATCH") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. packet_queue
-	if !execute("PATCH", m.getOperationCount("packet_queue", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "PATCH") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. sflow_collector
-	if !execute("PATCH", m.getOperationCount("sflow_collector", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "PATCH") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. gateway
-	if !execute("PATCH", m.getOperationCount("gateway", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway", "PATCH") }, "Gateway") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. lag
-	if !execute("PATCH", m.getOperationCount("lag", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "PATCH") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. eth_port_settings
-	if !execute("PATCH", m.getOperationCount("eth_port_settings", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "PATCH") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. diagnostics_profile
-	if !execute("PATCH", m.getOperationCount("diagnostics_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "PATCH") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. gateway_profile
-	if !execute("PATCH", m.getOperationCount("gateway_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway_profile", "PATCH") }, "Gateway Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 27. device_settings
-	if !execute("PATCH", m.getOperationCount("device_settings", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "PATCH") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 28. diagnostics_port_profile
-	if !execute("PATCH", m.getOperationCount("diagnostics_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "PATCH")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 29. bundle
-	if !execute("PATCH", m.getOperationCount("bundle", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "PATCH") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 30. pod
-	if !execute("PATCH", m.getOperationCount("pod", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pod", "PATCH") }, "Pod") {
-		return diagnostics, operationsPerformed
-	}
-	// 31. badge
-	if !execute("PATCH", m.getOperationCount("badge", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "PATCH") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 32. spine_plane
-	if !execute("PATCH", m.getOperationCount("spine_plane", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "spine_plane", "PATCH") }, "Spine Plane") {
-		return diagnostics, operationsPerformed
-	}
-	// 33. switchpoint
-	if !execute("PATCH", m.getOperationCount("switchpoint", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "PATCH") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 34. threshold
-	if !execute("PATCH", m.getOperationCount("threshold", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "PATCH") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 35. grouping_rule
-	if !execute("PATCH", m.getOperationCount("grouping_rule", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "PATCH") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 36. threshold_group
-	if !execute("PATCH", m.getOperationCount("threshold_group", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "PATCH") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 37. site
-	if !execute("PATCH", m.getOperationCount("site", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "site", "PATCH") }, "Site") {
-		return diagnostics, operationsPerformed
+			tflog.Info(ctx, "Successfully restored match_vrf fields via PATCH")
+		}
 	}
-	// 38. device_controller
-	if !execute("PATCH", m.getOperationCount("device_controller", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "PATCH") }, "Device Controller") {
-		return diagnostics, operationsPerformed
+
+	// PATCH operations
+	for _, level := range levels {
+		if failed := m.executeLevel(level, "PATCH", maxParallel, !continueOnError, execute); len(failed) > 0 && handleFailure(failed, "PATCH") {
+			return diagnostics, operationsPerformed
+		}
 	}
 
-	// DELETE operations - Reverse DC Order (note: sfp_breakout and site are skipped - they only support GET and PATCH)
+	// DELETE operations - reverse order (sfp_breakout, site and image_update_set are skipped - they only support GET and PATCH)
 
 	// CIRCULAR REFERENCE FIX FOR DELETE OPERATIONS
 	// Before starting DELETE operations, check if we need to handle circular references
@@ -1102,556 +870,97 @@ func (m *Manager) ExecuteDatacenterOperations(ctx context.Context) (diag.Diagnos
 			"affected_tenants": circularInfo.TenantNames,
 		})
 
-		m.mutex.Lock()
-		// Add PATCH operations to clear match_vrf from route_map_clauses being deleted
-		routeMapClauseOps := m.resources["route_map_clause"]
-		if routeMapClauseOps.Patch == nil {
-			routeMapClauseOps.Patch = make(map[string]interface{})
-		}
-
-		// For each clause in the circular set, create PATCH to clear match_vrf
-		for _, clauseName := range circularInfo.ClauseNames {
-			// Get the clause data from circularInfo.AffectedClauses (fetched from API)
-			var originalData interface{}
-			if clauseData, exists := circularInfo.AffectedClauses[clauseName]; exists {
-				originalData = clauseData
-			} else if putData, exists := routeMapClauseOps.Put[clauseName]; exists {
-				originalData = putData
-			}
-
-			// Create PATCH data with empty match_vrf
-			patchData := m.createMatchVrfPatchData(originalData, "")
-			routeMapClauseOps.Patch[clauseName] = patchData
-			tflog.Debug(ctx, fmt.Sprintf("Clearing match_vrf for route_map_clause: %s before deletion", clauseName))
-		}
-		m.mutex.Unlock()
+		m.prepareMatchVrfClear(ctx, circularInfo)
 
 		tflog.Info(ctx, "Executing PATCH to clear match_vrf references")
-		if !execute("PATCH", m.getOperationCount("route_map_clause", "PATCH"),
-			func(ctx context.Context) diag.Diagnostics {
-				return m.ExecuteBulk(ctx, "route_map_clause", "PATCH")
-			}, "Route Map Clause (clear match_vrf before deletion)") {
+		if !executeSingle("route_map_clause", "PATCH", "route_map_clause (clear match_vrf before deletion)") {
 			return diagnostics, operationsPerformed
 		}
-
-		// Clean up PATCH operations
-		m.mutex.Lock()
-		for _, clauseName := range circularInfo.ClauseNames {
-			delete(routeMapClauseOps.Patch, clauseName)
-		}
-		m.mutex.Unlock()
+		m.clearRouteMapClausePatches(circularInfo.ClauseNames)
 
 		tflog.Info(ctx, "Successfully cleared match_vrf references, proceeding with deletions")
 	}
 
-	// 38. device_controller
-	if !execute("DELETE", m.getOperationCount("device_controller", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "DELETE") }, "Device Controller") {
-		return diagnostics, operationsPerformed
-	}
-	// 36. threshold_group
-	if !execute("DELETE", m.getOperationCount("threshold_group", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "DELETE") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 35. grouping_rule
-	if !execute("DELETE", m.getOperationCount("grouping_rule", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "DELETE") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 34. threshold
-	if !execute("DELETE", m.getOperationCount("threshold", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "DELETE") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 33. switchpoint
-	if !execute("DELETE", m.getOperationCount("switchpoint", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "DELETE") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 32. spine_plane
-	if !execute("DELETE", m.getOperationCount("spine_plane", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "spine_plane", "DELETE") }, "Spine Plane") {
-		return diagnostics, operationsPerformed
-	}
-	// 31. badge
-	if !execute("DELETE", m.getOperationCount("badge", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "DELETE") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 30. pod
-	if !execute("DELETE", m.getOperationCount("pod", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pod", "DELETE") }, "Pod") {
-		return diagnostics, operationsPerformed
-	}
-	// 29. bundle
-	if !execute("DELETE", m.getOperationCount("bundle", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "DELETE") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 28. diagnostics_port_profile
-	if !execute("DELETE", m.getOperationCount("diagnostics_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "DELETE")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 27. device_settings
-	if !execute("DELETE", m.getOperationCount("device_settings", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "DELETE") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. gateway_profile
-	if !execute("DELETE", m.getOperationCount("gateway_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway_profile", "DELETE") }, "Gateway Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. diagnostics_profile
-	if !execute("DELETE", m.getOperationCount("diagnostics_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "DELETE") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. eth_port_settings
-	if !execute("DELETE", m.getOperationCount("eth_port_settings", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "DELETE") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. lag
-	if !execute("DELETE", m.getOperationCount("lag", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "DELETE") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. gateway
-	if !execute("DELETE", m.getOperationCount("gateway", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "gateway", "DELETE") }, "Gateway") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. sflow_collector
-	if !execute("DELETE", m.getOperationCount("sflow_collector", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "DELETE") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. packet_queue
-	if !execute("DELETE", m.getOperationCount("packet_queue", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "DELETE") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. eth_port_profile
-	if !execute("DELETE", m.getOperationCount("eth_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "DELETE") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. packet_broker
-	if !execute("DELETE", m.getOperationCount("packet_broker", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_broker", "DELETE") }, "Packet Broker") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. port_acl
-	if !execute("DELETE", m.getOperationCount("port_acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "DELETE") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. service
-	if !execute("DELETE", m.getOperationCount("service", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "DELETE") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. ipv6_list
-	if !execute("DELETE", m.getOperationCount("ipv6_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "DELETE") }, "IPv6 List Filter") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. ipv4_list
-	if !execute("DELETE", m.getOperationCount("ipv4_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "DELETE") }, "IPv4 List Filter") {
-		return diagnostics, operationsPerformed
-	}
-	// 13. pb_routing
-	if !execute("DELETE", m.getOperationCount("pb_routing", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "DELETE") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. tenant
-	if !execute("DELETE", m.getOperationCount("tenant", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "tenant", "DELETE") }, "Tenant") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. pb_routing_acl
-	if !execute("DELETE", m.getOperationCount("pb_routing_acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "DELETE") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. route_map
-	if !execute("DELETE", m.getOperationCount("route_map", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map", "DELETE") }, "Route Map") {
-		return diagnostics, operationsPerformed
-	}
-	// 8-9. acl (both ipv4 and ipv6)
-	if !execute("DELETE", m.getOperationCount("acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "DELETE") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 7. route_map_clause
-	if !execute("DELETE", m.getOperationCount("route_map_clause", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "route_map_clause", "DELETE") }, "Route Map Clause") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. as_path_access_list
-	if !execute("DELETE", m.getOperationCount("as_path_access_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "as_path_access_list", "DELETE") }, "AS Path Access List") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. extended_community_list
-	if !execute("DELETE", m.getOperationCount("extended_community_list", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "extended_community_list", "DELETE")
-	}, "Extended Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 4. ipv4_prefix_list
-	if !execute("DELETE", m.getOperationCount("ipv4_prefix_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_prefix_list", "DELETE") }, "IPv4 Prefix List") {
-		return diagnostics, operationsPerformed
-	}
-	// 3. community_list
-	if !execute("DELETE", m.getOperationCount("community_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "community_list", "DELETE") }, "Community List") {
-		return diagnostics, operationsPerformed
-	}
-	// 2. ipv6_prefix_list
-	if !execute("DELETE", m.getOperationCount("ipv6_prefix_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_prefix_list", "DELETE") }, "IPv6 Prefix List") {
-		return diagnostics, operationsPerformed
+	// a failed PUT or PATCH does not keep other objects from being deleted
+	cancelledBy = make(map[string]string)
+	for i := len(levels) - 1; i >= 0; i-- {
+		if failed := m.executeLevel(reversedLevel(levels[i]), "DELETE", maxParallel, !continueOnError, execute); len(failed) > 0 && handleFailure(failed, "DELETE") {
+			return diagnostics, operationsPerformed
+		}
 	}
 
 	return diagnostics, operationsPerformed
 }
 
-func (m *Manager) ExecuteCampusOperations(ctx context.Context) (diag.Diagnostics, bool) {
-	var diagnostics diag.Diagnostics
-	operationsPerformed := false
+// executeLevel runs the batches of one dependency level and returns the resource types whose batch failed.
+// The types in a level do not reference each other, so up to maxParallel batches are sent at once.
+// With stopOnFailure, once a batch fails no further batch of the level is started; batches already
+// in flight are allowed to finish so their operations get a definite result.
+func (m *Manager) executeLevel(level []string, opType string, maxParallel int, stopOnFailure bool, execute func(resourceType, opType, label string) bool) []string {
+	pending := make([]string, 0, len(level))
+	for _, resourceType := range level {
+		if m.getOperationCount(resourceType, opType) > 0 {
+			pending = append(pending, resourceType)
+		}
+	}
 
-	execute := func(opType string, count int, execFunc func(context.Context) diag.Diagnostics, resourceName string) bool {
-		if count > 0 {
-			tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] >>> Proceeding with %s %s for %d resource(s) — sending API request...", resourceName, opType, count))
-			diags := execFunc(ctx)
-			diagnostics.Append(diags...)
-			if diags.HasError() {
-				tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] <<< FAILED %s %s — aborting remaining operations", resourceName, opType))
-				err := fmt.Errorf("bulk %s %s operation failed", resourceName, opType)
-				m.FailAllPendingOperations(ctx, err)
-				return false
+	if maxParallel <= 1 || len(pending) <= 1 {
+		var failed []string
+		for _, resourceType := range pending {
+			if !execute(resourceType, opType, resourceType) {
+				failed = append(failed, resourceType)
+				if stopOnFailure {
+					break
+				}
 			}
-			tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] <<< Completed %s %s for %d resource(s) — success, moving to next type", resourceName, opType, count))
-			operationsPerformed = true
 		}
-		return true
+		return failed
 	}
 
-	// PUT operations - Campus Order (note: site is skipped - it only supports GET and PATCH)
-	// 1. ipv4_list
-	if !execute("PUT", m.getOperationCount("ipv4_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "PUT") }, "IPv4 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 2. ipv6_list
-	if !execute("PUT", m.getOperationCount("ipv6_list", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "PUT") }, "IPv6 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 3-4. acl (both ipv4 and ipv6)
-	if !execute("PUT", m.getOperationCount("acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "PUT") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. pb_routing_acl
-	if !execute("PUT", m.getOperationCount("pb_routing_acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "PUT") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. pb_routing
-	if !execute("PUT", m.getOperationCount("pb_routing", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "PUT") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 7. port_acl
-	if !execute("PUT", m.getOperationCount("port_acl", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "PUT") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 8. service
-	if !execute("PUT", m.getOperationCount("service", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "PUT") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 9. eth_port_profile
-	if !execute("PUT", m.getOperationCount("eth_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "PUT") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. sflow_collector
-	if !execute("PUT", m.getOperationCount("sflow_collector", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "PUT") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. packet_queue
-	if !execute("PUT", m.getOperationCount("packet_queue", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "PUT") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. service_port_profile
-	if !execute("PUT", m.getOperationCount("service_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service_port_profile", "PUT") }, "Service Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 13. diagnostics_port_profile
-	if !execute("PUT", m.getOperationCount("diagnostics_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "PUT")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. device_voice_settings
-	if !execute("PUT", m.getOperationCount("device_voice_settings", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_voice_settings", "PUT") }, "Device Voice Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. authenticated_eth_port
-	if !execute("PUT", m.getOperationCount("authenticated_eth_port", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "authenticated_eth_port", "PUT") }, "Authenticated Eth-Port") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. diagnostics_profile
-	if !execute("PUT", m.getOperationCount("diagnostics_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "PUT") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. eth_port_settings
-	if !execute("PUT", m.getOperationCount("eth_port_settings", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "PUT") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. voice_port_profile
-	if !execute("PUT", m.getOperationCount("voice_port_profile", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "voice_port_profile", "PUT") }, "Voice-Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. device_settings
-	if !execute("PUT", m.getOperationCount("device_settings", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "PUT") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. lag
-	if !execute("PUT", m.getOperationCount("lag", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "PUT") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. bundle
-	if !execute("PUT", m.getOperationCount("bundle", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "PUT") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. badge
-	if !execute("PUT", m.getOperationCount("badge", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "PUT") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. switchpoint
-	if !execute("PUT", m.getOperationCount("switchpoint", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "PUT") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. threshold
-	if !execute("PUT", m.getOperationCount("threshold", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "PUT") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. grouping_rule
-	if !execute("PUT", m.getOperationCount("grouping_rule", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "PUT") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. threshold_group
-	if !execute("PUT", m.getOperationCount("threshold_group", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "PUT") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 28. device_controller
-	if !execute("PUT", m.getOperationCount("device_controller", "PUT"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "PUT") }, "Device Controller") {
-		return diagnostics, operationsPerformed
+	var (
+		wg          sync.WaitGroup
+		failedMutex sync.Mutex
+		failed      []string
+	)
+	hasFailed := func() bool {
+		failedMutex.Lock()
+		defer failedMutex.Unlock()
+		return len(failed) > 0
 	}
 
-	// PATCH operations - Campus Order
-	// 1. ipv4_list
-	if !execute("PATCH", m.getOperationCount("ipv4_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "PATCH") }, "IPv4 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 2. ipv6_list
-	if !execute("PATCH", m.getOperationCount("ipv6_list", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "PATCH") }, "IPv6 List") {
-		return diagnostics, operationsPerformed
-	}
-	// 3-4. acl (both ipv4 and ipv6)
-	if !execute("PATCH", m.getOperationCount("acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "PATCH") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. pb_routing_acl
-	if !execute("PATCH", m.getOperationCount("pb_routing_acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "PATCH") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. pb_routing
-	if !execute("PATCH", m.getOperationCount("pb_routing", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "PATCH") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 7. port_acl
-	if !execute("PATCH", m.getOperationCount("port_acl", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "PATCH") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 8. service
-	if !execute("PATCH", m.getOperationCount("service", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "PATCH") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 9. eth_port_profile
-	if !execute("PATCH", m.getOperationCount("eth_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "PATCH") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. sflow_collector
-	if !execute("PATCH", m.getOperationCount("sflow_collector", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "PATCH") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. packet_queue
-	if !execute("PATCH", m.getOperationCount("packet_queue", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "PATCH") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. service_port_profile
-	if !execute("PATCH", m.getOperationCount("service_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service_port_profile", "PATCH") }, "Service Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 13. diagnostics_port_profile
-	if !execute("PATCH", m.getOperationCount("diagnostics_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "PATCH")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. device_voice_settings
-	if !execute("PATCH", m.getOperationCount("device_voice_settings", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "device_voice_settings", "PATCH")
-	}, "Device Voice Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. authenticated_eth_port
-	if !execute("PATCH", m.getOperationCount("authenticated_eth_port", "PATCH"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "authenticated_eth_port", "PATCH")
-	}, "Authenticated Eth-Port") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. diagnostics_profile
-	if !execute("PATCH", m.getOperationCount("diagnostics_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "PATCH") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. eth_port_settings
-	if !execute("PATCH", m.getOperationCount("eth_port_settings", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "PATCH") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. voice_port_profile
-	if !execute("PATCH", m.getOperationCount("voice_port_profile", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "voice_port_profile", "PATCH") }, "Voice-Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. device_settings
-	if !execute("PATCH", m.getOperationCount("device_settings", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "PATCH") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. lag
-	if !execute("PATCH", m.getOperationCount("lag", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "PATCH") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. bundle
-	if !execute("PATCH", m.getOperationCount("bundle", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "PATCH") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. badge
-	if !execute("PATCH", m.getOperationCount("badge", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "PATCH") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. switchpoint
-	if !execute("PATCH", m.getOperationCount("switchpoint", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "PATCH") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. threshold
-	if !execute("PATCH", m.getOperationCount("threshold", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "PATCH") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. grouping_rule
-	if !execute("PATCH", m.getOperationCount("grouping_rule", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "PATCH") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. threshold_group
-	if !execute("PATCH", m.getOperationCount("threshold_group", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "PATCH") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 27. site
-	if !execute("PATCH", m.getOperationCount("site", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "site", "PATCH") }, "Site") {
-		return diagnostics, operationsPerformed
-	}
-	// 28. device_controller
-	if !execute("PATCH", m.getOperationCount("device_controller", "PATCH"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "PATCH") }, "Device Controller") {
-		return diagnostics, operationsPerformed
-	}
+	semaphore := make(chan struct{}, maxParallel)
+	for _, resourceType := range pending {
+		semaphore <- struct{}{}
+		if stopOnFailure && hasFailed() {
+			<-semaphore
+			break
+		}
 
-	// DELETE operations - Reverse Campus Order (note: site is skipped - it only supports GET and PATCH)
-	// 28. device_controller
-	if !execute("DELETE", m.getOperationCount("device_controller", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_controller", "DELETE") }, "Device Controller") {
-		return diagnostics, operationsPerformed
-	}
-	// 26. threshold_group
-	if !execute("DELETE", m.getOperationCount("threshold_group", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold_group", "DELETE") }, "Threshold Group") {
-		return diagnostics, operationsPerformed
-	}
-	// 25. grouping_rule
-	if !execute("DELETE", m.getOperationCount("grouping_rule", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "grouping_rule", "DELETE") }, "Grouping Rule") {
-		return diagnostics, operationsPerformed
-	}
-	// 24. threshold
-	if !execute("DELETE", m.getOperationCount("threshold", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "threshold", "DELETE") }, "Threshold") {
-		return diagnostics, operationsPerformed
-	}
-	// 23. switchpoint
-	if !execute("DELETE", m.getOperationCount("switchpoint", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "switchpoint", "DELETE") }, "Switchpoint") {
-		return diagnostics, operationsPerformed
-	}
-	// 22. badge
-	if !execute("DELETE", m.getOperationCount("badge", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "badge", "DELETE") }, "Badge") {
-		return diagnostics, operationsPerformed
-	}
-	// 21. bundle
-	if !execute("DELETE", m.getOperationCount("bundle", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "bundle", "DELETE") }, "Bundle") {
-		return diagnostics, operationsPerformed
-	}
-	// 20. lag
-	if !execute("DELETE", m.getOperationCount("lag", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "lag", "DELETE") }, "LAG") {
-		return diagnostics, operationsPerformed
-	}
-	// 19. device_settings
-	if !execute("DELETE", m.getOperationCount("device_settings", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "device_settings", "DELETE") }, "Device Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 18. voice_port_profile
-	if !execute("DELETE", m.getOperationCount("voice_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "voice_port_profile", "DELETE") }, "Voice-Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 17. eth_port_settings
-	if !execute("DELETE", m.getOperationCount("eth_port_settings", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_settings", "DELETE") }, "Eth Port Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 16. diagnostics_profile
-	if !execute("DELETE", m.getOperationCount("diagnostics_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "diagnostics_profile", "DELETE") }, "Diagnostics Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 15. authenticated_eth_port
-	if !execute("DELETE", m.getOperationCount("authenticated_eth_port", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "authenticated_eth_port", "DELETE")
-	}, "Authenticated Eth-Port") {
-		return diagnostics, operationsPerformed
-	}
-	// 14. device_voice_settings
-	if !execute("DELETE", m.getOperationCount("device_voice_settings", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "device_voice_settings", "DELETE")
-	}, "Device Voice Settings") {
-		return diagnostics, operationsPerformed
-	}
-	// 13. diagnostics_port_profile
-	if !execute("DELETE", m.getOperationCount("diagnostics_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "diagnostics_port_profile", "DELETE")
-	}, "Diagnostics Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 12. service_port_profile
-	if !execute("DELETE", m.getOperationCount("service_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics {
-		return m.ExecuteBulk(ctx, "service_port_profile", "DELETE")
-	}, "Service Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 11. packet_queue
-	if !execute("DELETE", m.getOperationCount("packet_queue", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "packet_queue", "DELETE") }, "Packet Queue") {
-		return diagnostics, operationsPerformed
-	}
-	// 10. sflow_collector
-	if !execute("DELETE", m.getOperationCount("sflow_collector", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "sflow_collector", "DELETE") }, "SFlow Collector") {
-		return diagnostics, operationsPerformed
-	}
-	// 9. eth_port_profile
-	if !execute("DELETE", m.getOperationCount("eth_port_profile", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "eth_port_profile", "DELETE") }, "Eth Port Profile") {
-		return diagnostics, operationsPerformed
-	}
-	// 8. service
-	if !execute("DELETE", m.getOperationCount("service", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "service", "DELETE") }, "Service") {
-		return diagnostics, operationsPerformed
-	}
-	// 7. port_acl
-	if !execute("DELETE", m.getOperationCount("port_acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "port_acl", "DELETE") }, "Port ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 6. pb_routing
-	if !execute("DELETE", m.getOperationCount("pb_routing", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing", "DELETE") }, "PB Routing") {
-		return diagnostics, operationsPerformed
-	}
-	// 5. pb_routing_acl
-	if !execute("DELETE", m.getOperationCount("pb_routing_acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "pb_routing_acl", "DELETE") }, "PB Routing ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 3-4. acl (both ipv4 and ipv6)
-	if !execute("DELETE", m.getOperationCount("acl", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "acl", "DELETE") }, "ACL") {
-		return diagnostics, operationsPerformed
-	}
-	// 2. ipv6_list
-	if !execute("DELETE", m.getOperationCount("ipv6_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv6_list", "DELETE") }, "IPv6 List Filter") {
-		return diagnostics, operationsPerformed
-	}
-	// 1. ipv4_list
-	if !execute("DELETE", m.getOperationCount("ipv4_list", "DELETE"), func(ctx context.Context) diag.Diagnostics { return m.ExecuteBulk(ctx, "ipv4_list", "DELETE") }, "IPv4 List Filter") {
-		return diagnostics, operationsPerformed
+		wg.Add(1)
+		go func(resourceType string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if !execute(resourceType, opType, resourceType) {
+				failedMutex.Lock()
+				failed = append(failed, resourceType)
+				failedMutex.Unlock()
+			}
+		}(resourceType)
 	}
+	wg.Wait()
 
-	return diagnostics, operationsPerformed
+	sort.Strings(failed)
+	return failed
+}
+
+// reversedLevel returns a copy of level in reverse order, so sequential DELETEs mirror the PUT order.
+func reversedLevel(level []string) []string {
+	reversed := make([]string, len(level))
+	for i, resourceType := range level {
+		reversed[len(level)-1-i] = resourceType
+	}
+	return reversed
 }
 
 func (m *Manager) ShouldExecuteOperations(ctx context.Context) bool {
@@ -1668,7 +977,7 @@ func (m *Manager) ShouldExecuteOperations(ctx context.Context) bool {
 
 	// Only flush if either sufficient time has passed since the last operation
 	// OR the batch has been open for too long
-	if elapsedSinceLast < BatchCollectionWindow && elapsedSinceBatchStart < MaxBatchDelay {
+	if elapsedSinceLast < m.settings.BatchCollectionWindow && elapsedSinceBatchStart < m.settings.MaxBatchDelay {
 		return false
 	}
 
@@ -1801,6 +1110,8 @@ func (m *Manager) ExecuteIfMultipleOperations(ctx context.Context) diag.Diagnost
 
 	sitePatchCount := m.getOperationCountLocked("site", "PATCH")
 
+	imageUpdateSetPatchCount := m.getOperationCountLocked("image_update_set", "PATCH")
+
 	podPutCount := m.getOperationCountLocked("pod", "PUT")
 	podPatchCount := m.getOperationCountLocked("pod", "PATCH")
 	podDeleteCount := m.getOperationCountLocked("pod", "DELETE")
@@ -1961,6 +1272,7 @@ func (m *Manager) ExecuteIfMultipleOperations(ctx context.Context) diag.Diagnost
 			"route_map_delete_count":                routeMapDeleteCount,
 			"sfp_breakout_patch_count":              sfpBreakoutPatchCount,
 			"site_patch_count":                      sitePatchCount,
+			"image_update_set_patch_count":          imageUpdateSetPatchCount,
 			"pod_put_count":                         podPutCount,
 			"pod_patch_count":                       podPatchCount,
 			"pod_delete_count":                      podDeleteCount,
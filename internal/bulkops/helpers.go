@@ -134,7 +134,7 @@ func (m *Manager) createPreExistenceChecker(config ResourceConfig, operationType
 			ResourceType:  config.ResourceType,
 			OperationType: "PUT",
 			FetchResources: func(ctx context.Context) (map[string]interface{}, error) {
-				apiCtx, cancel := context.WithTimeout(context.Background(), OperationTimeout)
+				apiCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
 				defer cancel()
 
 				switch config.ResourceType {
@@ -977,6 +977,15 @@ func (m *Manager) createRequestPreparer(config ResourceConfig, operationType str
 			}
 			patchRequest.SetSite(siteMap)
 			return patchRequest
+		case "image_update_set":
+			// Image Update Sets only support PATCH operations
+			patchRequest := openapi.NewImageupdatesetsPatchRequest()
+			imageUpdateSetMap := make(map[string]openapi.ImageupdatesetsPatchRequestImageUpdateSetsValue)
+			for name, props := range filteredData {
+				imageUpdateSetMap[name] = props.(openapi.ImageupdatesetsPatchRequestImageUpdateSetsValue)
+			}
+			patchRequest.SetImageUpdateSets(imageUpdateSetMap)
+			return patchRequest
 		case "packet_broker":
 			putRequest := openapi.NewPacketbrokerPutRequest()
 			brokerMap := make(map[string]openapi.PacketbrokerPutRequestPbEgressProfileValue)
@@ -1150,11 +1159,11 @@ func (m *Manager) createHeaderAwareResponseProcessor(config ResourceConfig, oper
 // When headers is nil, it uses GetFunc; when headers is provided, it uses HeaderGetFunc.
 func (m *Manager) createResponseProcessorWithHeaders(config ResourceConfig, operationType string, headers map[string]string) func(context.Context, *http.Response) error {
 	return func(ctx context.Context, resp *http.Response) error {
-		delayTime := ResponseProcessorDelay
+		delayTime := m.settings.ResponseProcessorDelay
 		tflog.Debug(ctx, fmt.Sprintf("Waiting %v for server values to be assigned before fetching %s", delayTime, config.ResourceType))
 		time.Sleep(delayTime)
 
-		fetchCtx, fetchCancel := context.WithTimeout(context.Background(), OperationTimeout)
+		fetchCtx, fetchCancel := context.WithTimeout(context.Background(), m.operationTimeout)
 		defer fetchCancel()
 
 		if headers != nil {
@@ -1172,8 +1181,9 @@ func (m *Manager) createResponseProcessorWithHeaders(config ResourceConfig, oper
 	}
 }
 
-// fetchAndCacheResourceResponse fetches resource data and caches it.
-func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx context.Context, config ResourceConfig, res *ResourceOperations, headers map[string]string) error {
+// fetchResourceData fetches all objects of a resource type, keyed by name. It returns nil
+// without an error when the type cannot be fetched.
+func (m *Manager) fetchResourceData(fetchCtx context.Context, logCtx context.Context, config ResourceConfig, headers map[string]string) (map[string]interface{}, error) {
 	var getResp *http.Response
 	var fetchErr error
 
@@ -1181,14 +1191,14 @@ func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx
 		// Header-aware fetch for resources like ACLs with ip_version
 		if config.HeaderGetFunc == nil {
 			tflog.Debug(logCtx, fmt.Sprintf("No HeaderGetFunc defined for %s, skipping response caching", config.ResourceType))
-			return nil
+			return nil, nil
 		}
 		getResp, fetchErr = config.HeaderGetFunc(m.client, fetchCtx, headers)
 	} else {
 		// Standard fetch for regular resources
 		if config.GetFunc == nil {
 			tflog.Debug(logCtx, fmt.Sprintf("No GetFunc defined for %s, skipping response caching", config.ResourceType))
-			return nil
+			return nil, nil
 		}
 		getResp, fetchErr = config.GetFunc(m.client, fetchCtx)
 	}
@@ -1199,7 +1209,7 @@ func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx
 			logFields["headers"] = headers
 		}
 		tflog.Error(logCtx, fmt.Sprintf("Failed to fetch %s after operation", config.ResourceType), logFields)
-		return fetchErr
+		return nil, fetchErr
 	}
 	defer getResp.Body.Close()
 
@@ -1209,7 +1219,7 @@ func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx
 		tflog.Error(logCtx, fmt.Sprintf("Failed to decode %s response", config.ResourceType), map[string]interface{}{
 			"error": respErr.Error(),
 		})
-		return respErr
+		return nil, respErr
 	}
 
 	// Extract resource data - use HeaderResponseExtractor if available and headers provided
@@ -1221,23 +1231,33 @@ func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx
 			tflog.Error(logCtx, fmt.Sprintf("Failed to extract %s response data", config.ResourceType), map[string]interface{}{
 				"error": err.Error(),
 			})
-			return err
+			return nil, err
 		}
 	} else {
 		// Standard JSON key lookup
 		jsonKey := utils.GetResourceJSONKey(config.ResourceType)
 		if jsonKey == "" {
 			tflog.Warn(logCtx, fmt.Sprintf("No JSON key mapping found for resource type: %s", config.ResourceType))
-			return nil
+			return nil, nil
 		}
 		var ok bool
 		resourceData, ok = rawResponse[jsonKey].(map[string]interface{})
 		if !ok {
 			tflog.Debug(logCtx, fmt.Sprintf("No %s data found in response or unexpected format", jsonKey))
-			return nil
+			return nil, nil
 		}
 	}
 
+	return resourceData, nil
+}
+
+// fetchAndCacheResourceResponse fetches resource data and caches it.
+func (m *Manager) fetchAndCacheResourceResponse(fetchCtx context.Context, logCtx context.Context, config ResourceConfig, res *ResourceOperations, headers map[string]string) error {
+	resourceData, err := m.fetchResourceData(fetchCtx, logCtx, config, headers)
+	if err != nil || resourceData == nil {
+		return err
+	}
+
 	// Cache the response data
 	res.ResponsesMutex.Lock()
 	for resourceName, data := range resourceData {
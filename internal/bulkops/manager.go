@@ -6,6 +6,8 @@ import (
 	"sync"
 	"terraform-provider-verity/openapi"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // ================================================================================================
@@ -35,6 +37,12 @@ type Manager struct {
 	batchStartTime    time.Time
 	resources         map[string]*ResourceOperations
 
+	// operationTimeout bounds each bulk API request and the wait for an operation to complete
+	operationTimeout time.Duration
+
+	// settings holds the batch sizes and timing, set once when the provider is configured
+	settings Settings
+
 	// resourceHeaderParams tracks header parameters for operations that need them
 	// Key format: "resourceType:compositeKey" -> map of header params
 	// Example: "acl:my_filter_ip_version4" -> {"ip_version": "4"}
@@ -52,6 +60,16 @@ type Manager struct {
 	operationWaitChannels map[string]chan struct{}
 	operationMutex        sync.Mutex
 	closedChannels        map[string]bool
+
+	// dryRun records the batches instead of sending them when set, guarded by mutex
+	dryRun *dryRun
+
+	// operationJournal records the intent and outcome of every batch sent when set, guarded by mutex
+	operationJournal *operationJournal
+
+	// journal records how to undo the batches of the run in progress when RollbackOnFailure
+	// is set, guarded by operationMutex
+	journal *rollbackJournal
 }
 
 // ================================================================================================
@@ -79,6 +97,8 @@ func NewManager(client *openapi.APIClient, contextProvider ContextProviderFunc,
 		mode:                  mode,
 		lastOperationTime:     time.Now(),
 		resources:             initializeResourceOperations(),
+		operationTimeout:      DefaultOperationTimeout,
+		settings:              DefaultSettings(),
 		resourceHeaderParams:  make(map[string]map[string]string),
 		resourceOriginalNames: make(map[string]string),
 		pendingOperations:     make(map[string]*Operation),
@@ -121,6 +141,53 @@ func (m *Manager) GetResourceResponse(resourceType, resourceName string) (map[st
 	return response, exists
 }
 
+// SetOperationTimeout sets the timeout of each bulk API request and of waiting for an operation
+// to complete. Values of 0 or below restore DefaultOperationTimeout.
+func (m *Manager) SetOperationTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultOperationTimeout
+	}
+	m.operationTimeout = timeout
+}
+
+// OperationTimeout returns the timeout of each bulk API request.
+func (m *Manager) OperationTimeout() time.Duration {
+	return m.operationTimeout
+}
+
+// SetSettings sets the batch sizes and timing. Durations and sizes of 0 or below keep their
+// defaults from DefaultSettings.
+func (m *Manager) SetSettings(settings Settings) {
+	defaults := DefaultSettings()
+	if settings.BatchCollectionWindow <= 0 {
+		settings.BatchCollectionWindow = defaults.BatchCollectionWindow
+	}
+	if settings.MaxBatchDelay <= 0 {
+		settings.MaxBatchDelay = defaults.MaxBatchDelay
+	}
+	if settings.ResponseProcessorDelay < 0 {
+		settings.ResponseProcessorDelay = defaults.ResponseProcessorDelay
+	}
+	if settings.DebounceDelay <= 0 {
+		settings.DebounceDelay = defaults.DebounceDelay
+	}
+	if settings.MaxBatchSize <= 0 {
+		settings.MaxBatchSize = defaults.MaxBatchSize
+	}
+	if settings.MaxDeleteBatchSize <= 0 {
+		settings.MaxDeleteBatchSize = defaults.MaxDeleteBatchSize
+	}
+	if settings.MaxParallelBatches <= 0 {
+		settings.MaxParallelBatches = defaults.MaxParallelBatches
+	}
+	m.settings = settings
+}
+
+// Settings returns the batch sizes and timing in use.
+func (m *Manager) Settings() Settings {
+	return m.settings
+}
+
 // HasPendingOrRecentOperations checks if a resource type has pending or recent operations.
 func (m *Manager) HasPendingOrRecentOperations(resourceType string) bool {
 	return m.hasPendingOrRecentOperations(resourceType)
@@ -157,6 +224,63 @@ func (m *Manager) FailAllPendingOperations(ctx context.Context, err error) {
 	}
 }
 
+// cancelOperations fails the pending operationType operations of resourceType and drops them
+// from the queue, so they are not sent in a later pass.
+func (m *Manager) cancelOperations(ctx context.Context, resourceType, operationType string, err error) {
+	m.mutex.Lock()
+	if res, exists := m.resources[resourceType]; exists {
+		switch operationType {
+		case "PUT":
+			for name := range res.Put {
+				m.forgetHeaderParams(resourceType, name)
+			}
+			res.Put = make(map[string]interface{})
+		case "PATCH":
+			for name := range res.Patch {
+				m.forgetHeaderParams(resourceType, name)
+			}
+			res.Patch = make(map[string]interface{})
+		case "DELETE":
+			for _, name := range res.Delete {
+				m.forgetHeaderParams(resourceType, name)
+			}
+			res.Delete = res.Delete[:0]
+		}
+	}
+	m.mutex.Unlock()
+
+	m.operationMutex.Lock()
+	var idsToClose []string
+	for opID, op := range m.pendingOperations {
+		if op.ResourceType != resourceType || op.OperationType != operationType || op.Status != OperationPending {
+			continue
+		}
+		updatedOp := op
+		updatedOp.Status = OperationFailed
+		updatedOp.Error = fmt.Errorf("operation cancelled due to previous failure: %v", err)
+		m.pendingOperations[opID] = updatedOp
+		m.operationErrors[opID] = updatedOp.Error
+		m.operationResults[opID] = false
+		idsToClose = append(idsToClose, opID)
+	}
+	m.operationMutex.Unlock()
+
+	for _, opID := range idsToClose {
+		m.safeCloseChannel(opID, false)
+	}
+
+	if len(idsToClose) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Cancelled %d %s %s operations: %v", len(idsToClose), resourceType, operationType, err))
+	}
+}
+
+// forgetHeaderParams drops the header parameters stored for a queued operation. Callers hold m.mutex.
+func (m *Manager) forgetHeaderParams(resourceType, key string) {
+	paramKey := fmt.Sprintf("%s:%s", resourceType, key)
+	delete(m.resourceHeaderParams, paramKey)
+	delete(m.resourceOriginalNames, paramKey)
+}
+
 // safeCloseChannel safely closes an operation's wait channel.
 // The lockAlreadyHeld parameter indicates if the caller already holds the operationMutex.
 func (m *Manager) safeCloseChannel(opID string, lockAlreadyHeld bool) {
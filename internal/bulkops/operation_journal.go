@@ -0,0 +1,360 @@
+package bulkops
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ================================================================================================
+// OPERATION JOURNAL
+// ================================================================================================
+//
+// The operation journal survives the provider process, unlike the operation tracking maps.
+// Before a batch is sent, a line with its intent is appended to the journal; once the API
+// answered, a line with its outcome follows. A batch without an outcome was in flight when
+// the process stopped, so its objects may or may not have been changed. The next time the
+// provider is configured, those batches are checked against the objects the API returns.
+// Configuring the provider does not change the journal, as a plan sends no batches: the
+// journal only starts over, with the batches that could not be checked, when the first batch
+// of the run is recorded. A plan therefore reports the same batches again. Every controller and
+// changeset has its own journal, so provider configurations sharing a working directory do
+// not check or remove each other's batches.
+// ================================================================================================
+
+// OperationJournalFile returns the name of the operation journal, in the provider working
+// directory, of the provider configured for uri and changeset.
+func OperationJournalFile(uri, changeset string) string {
+	sum := sha256.Sum256([]byte(uri + "\n" + changeset))
+	return fmt.Sprintf(".verity-operations-%x.journal", sum[:6])
+}
+
+// Outcomes recorded for a batch in the operation journal.
+const (
+	journalSucceeded = "succeeded"
+	journalFailed    = "failed"
+)
+
+// JournalEntry is a line of the operation journal: the intent to send a batch, or the outcome
+// of the batch with the same ID.
+type JournalEntry struct {
+	ID           string            `json:"id"`
+	Time         time.Time         `json:"time"`
+	ResourceType string            `json:"resource_type,omitempty"`
+	Method       string            `json:"method,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	Names        []string          `json:"names,omitempty"`
+	Outcome      string            `json:"outcome,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// InterruptedBatch is a batch that was sent without an outcome being recorded, with the
+// objects it names split by whether the API returns them now.
+type InterruptedBatch struct {
+	ResourceType string
+	Method       string
+	Headers      map[string]string
+	Names        []string
+	Sent         time.Time
+	Present      []string
+	Absent       []string
+	// Err is set when the objects could not be read, Present and Absent are then empty
+	Err error
+}
+
+// String describes the batch and what became of its objects.
+func (b InterruptedBatch) String() string {
+	description := fmt.Sprintf("%s %s sent at %s", b.ResourceType, b.Method, b.Sent.Format(time.RFC3339))
+	if len(b.Headers) > 0 {
+		description += fmt.Sprintf(" with %v", b.Headers)
+	}
+	if b.Err != nil {
+		return fmt.Sprintf("%s: %s (could not be checked, kept for the next run: %v)", description, strings.Join(b.Names, ", "), b.Err)
+	}
+
+	var present, absent string
+	switch b.Method {
+	case "PUT":
+		present, absent = "created", "not created"
+	case "PATCH":
+		present, absent = "possibly partially updated", "not found"
+	case "DELETE":
+		present, absent = "not deleted", "deleted"
+	default:
+		present, absent = "present", "absent"
+	}
+
+	var parts []string
+	if len(b.Present) > 0 {
+		parts = append(parts, fmt.Sprintf("%s: %s", present, strings.Join(b.Present, ", ")))
+	}
+	if len(b.Absent) > 0 {
+		parts = append(parts, fmt.Sprintf("%s: %s", absent, strings.Join(b.Absent, ", ")))
+	}
+	return fmt.Sprintf("%s; %s", description, strings.Join(parts, "; "))
+}
+
+// operationJournal appends the intent and outcome of every batch to a file.
+type operationJournal struct {
+	mu   sync.Mutex
+	path string
+
+	// recovered is set once RecoverOperationJournal read the file, which is then replaced by
+	// unchecked before the first entry of the run is appended
+	recovered bool
+	unchecked []JournalEntry
+}
+
+// SetOperationJournal makes the manager record every batch it sends in the journal at path.
+// An empty path disables the journal.
+func (m *Manager) SetOperationJournal(path string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if path == "" {
+		m.operationJournal = nil
+		return
+	}
+	m.operationJournal = &operationJournal{path: path}
+}
+
+func (m *Manager) activeOperationJournal() *operationJournal {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.operationJournal
+}
+
+// recordIntent appends the intent to send a batch and returns its ID. Failing to write the
+// journal does not stop the batch, it is only logged.
+func (j *operationJournal) recordIntent(ctx context.Context, config BulkOperationConfig, resourceNames []string) string {
+	names := append([]string(nil), resourceNames...)
+	sort.Strings(names)
+	entry := JournalEntry{
+		ID:           uuid.New().String(),
+		Time:         time.Now().UTC(),
+		ResourceType: config.ResourceType,
+		Method:       config.OperationType,
+		Headers:      config.Headers,
+		Names:        names,
+	}
+	j.append(ctx, entry)
+	return entry.ID
+}
+
+// recordOutcome appends the outcome of the batch with the given ID.
+func (j *operationJournal) recordOutcome(ctx context.Context, id string, opErr error) {
+	entry := JournalEntry{ID: id, Time: time.Now().UTC(), Outcome: journalSucceeded}
+	if opErr != nil {
+		entry.Outcome = journalFailed
+		entry.Error = opErr.Error()
+	}
+	j.append(ctx, entry)
+}
+
+func (j *operationJournal) append(ctx context.Context, entry JournalEntry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.recovered {
+		if err := writeJournalEntries(j.path, j.unchecked); err != nil {
+			tflog.Warn(ctx, "[BULK-OPS] Failed to rewrite operation journal", map[string]interface{}{
+				"path":  j.path,
+				"error": err.Error(),
+			})
+		}
+		j.recovered, j.unchecked = false, nil
+	}
+
+	err := func() error {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}()
+	if err != nil {
+		tflog.Warn(ctx, "[BULK-OPS] Failed to write operation journal", map[string]interface{}{
+			"path":  j.path,
+			"error": err.Error(),
+		})
+	}
+}
+
+// RecoverOperationJournal checks the batches of the operation journal that were sent without
+// an outcome against the objects the API returns. It leaves the file as it is: once the run
+// records its first batch, the journal is replaced by the batches whose objects could not be
+// read, to be checked again by the next run. It returns nothing when no journal is set or the
+// journal does not exist.
+func (m *Manager) RecoverOperationJournal(ctx context.Context) ([]InterruptedBatch, error) {
+	journal := m.activeOperationJournal()
+	if journal == nil {
+		return nil, nil
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	intents, err := readInterruptedIntents(journal.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read operation journal %s: %w", journal.path, err)
+	}
+
+	var interrupted []InterruptedBatch
+	if len(intents) > 0 {
+		tflog.Warn(ctx, fmt.Sprintf("[BULK-OPS] Operation journal has %d batch(es) without an outcome, checking their objects", len(intents)))
+		interrupted = m.checkInterruptedBatches(ctx, intents)
+	}
+
+	var unchecked []JournalEntry
+	for i, batch := range interrupted {
+		if batch.Err != nil {
+			unchecked = append(unchecked, intents[i])
+		}
+	}
+	journal.recovered, journal.unchecked = true, unchecked
+	return interrupted, nil
+}
+
+// writeJournalEntries replaces the journal at path with entries. The entries are written to a
+// temporary file first, so the journal is never left half written.
+func writeJournalEntries(path string, entries []JournalEntry) error {
+	var data []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// readInterruptedIntents returns the intents of the journal at path that have no outcome, in
+// the order they were sent. A line that cannot be decoded, such as one cut short when the
+// process stopped, is skipped.
+func readInterruptedIntents(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var intents []JournalEntry
+	completed := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.ID == "" {
+			continue
+		}
+		if entry.Outcome != "" {
+			completed[entry.ID] = true
+			continue
+		}
+		intents = append(intents, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var interrupted []JournalEntry
+	for _, entry := range intents {
+		if !completed[entry.ID] {
+			interrupted = append(interrupted, entry)
+		}
+	}
+	return interrupted, nil
+}
+
+// checkInterruptedBatches reads the objects of every resource type named by the intents once
+// and splits the names of each intent by whether the object exists.
+func (m *Manager) checkInterruptedBatches(ctx context.Context, intents []JournalEntry) []InterruptedBatch {
+	type readResult struct {
+		data map[string]interface{}
+		err  error
+	}
+	reads := make(map[string]readResult)
+
+	batches := make([]InterruptedBatch, 0, len(intents))
+	for _, intent := range intents {
+		batch := InterruptedBatch{
+			ResourceType: intent.ResourceType,
+			Method:       intent.Method,
+			Headers:      intent.Headers,
+			Names:        intent.Names,
+			Sent:         intent.Time,
+		}
+
+		key := fmt.Sprintf("%s:%v", intent.ResourceType, intent.Headers)
+		result, done := reads[key]
+		if !done {
+			result.data, result.err = m.readJournalObjects(ctx, intent.ResourceType, intent.Headers)
+			reads[key] = result
+		}
+
+		if result.err != nil {
+			batch.Err = result.err
+		} else {
+			for _, name := range intent.Names {
+				if _, ok := result.data[name]; ok {
+					batch.Present = append(batch.Present, name)
+				} else {
+					batch.Absent = append(batch.Absent, name)
+				}
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// readJournalObjects fetches the objects of a resource type named in the journal. A fetch that
+// returns no data leaves it unknown which objects exist, so it is an error like a failed read.
+func (m *Manager) readJournalObjects(ctx context.Context, resourceType string, headers map[string]string) (map[string]interface{}, error) {
+	config, exists := resourceRegistry[resourceType]
+	if !exists {
+		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
+	}
+	if (headers != nil && config.HeaderGetFunc == nil) || (headers == nil && config.GetFunc == nil) {
+		return nil, fmt.Errorf("%s cannot be read", resourceType)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+	data, err := m.fetchResourceData(fetchCtx, ctx, config, headers)
+	if err == nil && data == nil {
+		return nil, fmt.Errorf("the %s objects could not be read", resourceType)
+	}
+	return data, err
+}
@@ -206,6 +206,7 @@ func (m *Manager) executeOperationsWithHeaders(ctx context.Context, resourceType
 	return m.executeBulkOperation(ctx, BulkOperationConfig{
 		ResourceType:  resourceType,
 		OperationType: operationType,
+		Headers:       headers,
 
 		ExtractOperations: func() (map[string]interface{}, []string) {
 			names := make([]string, 0, len(operations))
@@ -224,7 +225,7 @@ func (m *Manager) executeOperationsWithHeaders(ctx context.Context, resourceType
 				ResourceType:  resourceType,
 				OperationType: "PUT",
 				FetchResources: func(ctx context.Context) (map[string]interface{}, error) {
-					apiCtx, cancel := context.WithTimeout(context.Background(), OperationTimeout)
+					apiCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
 					defer cancel()
 
 					resp, err := config.HeaderGetFunc(m.client, apiCtx, headers)
@@ -19,11 +19,15 @@ import (
 //   - PutRequestType: The reflect.Type for PUT API requests for this resource
 //   - PatchRequestType: The reflect.Type for PATCH API requests for this resource
 //   - APIClientGetter: Function that returns a ResourceAPIClient for the resource type
+//   - DependsOn: Resource types referenced by this resource, used to compute execution order.
+//     It follows the *_ref_type_ enums of the API spec, except for the gateway -> site and
+//     route_map_clause -> tenant references, which would close a cycle with the reverse edges
 var resourceRegistry = map[string]ResourceConfig{
 	"gateway": {
 		ResourceType:     "gateway",
 		PutRequestType:   reflect.TypeOf(openapi.GatewaysPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.GatewaysPutRequest{}),
+		DependsOn:        []string{"route_map", "tenant"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "gateway"}
 		},
@@ -44,6 +48,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "lag",
 		PutRequestType:   reflect.TypeOf(openapi.LagsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.LagsPutRequest{}),
+		DependsOn:        []string{"eth_port_profile", "gateway_profile", "packet_broker", "service_port_profile"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "lag"}
 		},
@@ -64,6 +69,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "tenant",
 		PutRequestType:   reflect.TypeOf(openapi.TenantsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.TenantsPutRequest{}),
+		DependsOn:        []string{"route_map"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "tenant"}
 		},
@@ -84,6 +90,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "service",
 		PutRequestType:   reflect.TypeOf(openapi.ServicesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.ServicesPutRequest{}),
+		DependsOn:        []string{"pb_routing", "tenant"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "service"}
 		},
@@ -104,6 +111,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "gateway_profile",
 		PutRequestType:   reflect.TypeOf(openapi.GatewayprofilesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.GatewayprofilesPutRequest{}),
+		DependsOn:        []string{"gateway"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "gateway_profile"}
 		},
@@ -124,6 +132,8 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "grouping_rule",
 		PutRequestType:   reflect.TypeOf(openapi.GroupingrulesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.GroupingrulesPutRequest{}),
+		// rule_value_path is polymorphic; grouping rules are matched against switchpoint objects
+		DependsOn: []string{"switchpoint"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "grouping_rule"}
 		},
@@ -144,6 +154,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "threshold_group",
 		PutRequestType:   reflect.TypeOf(openapi.ThresholdgroupsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.ThresholdgroupsPutRequest{}),
+		DependsOn:        []string{"grouping_rule", "switchpoint", "threshold"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "threshold_group"}
 		},
@@ -204,6 +215,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "eth_port_profile",
 		PutRequestType:   reflect.TypeOf(openapi.EthportprofilesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.EthportprofilesPutRequest{}),
+		DependsOn:        []string{"port_acl", "service"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "eth_port_profile"}
 		},
@@ -224,6 +236,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "eth_port_settings",
 		PutRequestType:   reflect.TypeOf(openapi.EthportsettingsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.EthportsettingsPutRequest{}),
+		DependsOn:        []string{"packet_queue", "service"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "eth_port_settings"}
 		},
@@ -244,6 +257,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "bundle",
 		PutRequestType:   reflect.TypeOf(openapi.BundlesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.BundlesPutRequest{}),
+		DependsOn:        []string{"authenticated_eth_port", "device_settings", "device_voice_settings", "diagnostics_port_profile", "diagnostics_profile", "eth_port_profile", "eth_port_settings", "gateway_profile", "lag", "packet_broker", "service", "service_port_profile", "voice_port_profile"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "bundle"}
 		},
@@ -314,6 +328,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "packet_broker",
 		PutRequestType:   reflect.TypeOf(openapi.PacketbrokerPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.PacketbrokerPutRequest{}),
+		DependsOn:        []string{"acl", "ipv4_list", "ipv6_list"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "packet_broker"}
 		},
@@ -354,6 +369,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "switchpoint",
 		PutRequestType:   reflect.TypeOf(openapi.SwitchpointsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.SwitchpointsPutRequest{}),
+		DependsOn:        []string{"badge", "bundle", "pod", "spine_plane"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "switchpoint"}
 		},
@@ -374,6 +390,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "device_controller",
 		PutRequestType:   reflect.TypeOf(openapi.DevicecontrollersPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.DevicecontrollersPutRequest{}),
+		DependsOn:        []string{"service", "switchpoint"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "device_controller"}
 		},
@@ -394,6 +411,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "authenticated_eth_port",
 		PutRequestType:   reflect.TypeOf(openapi.AuthenticatedethportsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.AuthenticatedethportsPutRequest{}),
+		DependsOn:        []string{"eth_port_profile"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "authenticated_eth_port"}
 		},
@@ -454,6 +472,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "service_port_profile",
 		PutRequestType:   reflect.TypeOf(openapi.ServiceportprofilesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.ServiceportprofilesPutRequest{}),
+		DependsOn:        []string{"service"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "service_port_profile"}
 		},
@@ -514,6 +533,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "device_settings",
 		PutRequestType:   reflect.TypeOf(openapi.DevicesettingsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.DevicesettingsPutRequest{}),
+		DependsOn:        []string{"packet_queue"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "device_settings"}
 		},
@@ -634,6 +654,9 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "route_map_clause",
 		PutRequestType:   reflect.TypeOf(openapi.RoutemapclausesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.RoutemapclausesPutRequest{}),
+		// match_vrf can also reference a tenant, which references route maps in turn. That edge is
+		// resolved by the circular reference fix (see circular_reference.go) and is not declared here.
+		DependsOn: []string{"as_path_access_list", "community_list", "extended_community_list", "ipv4_prefix_list", "ipv6_prefix_list"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "route_map_clause"}
 		},
@@ -654,6 +677,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "route_map",
 		PutRequestType:   reflect.TypeOf(openapi.RoutemapsPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.RoutemapsPutRequest{}),
+		DependsOn:        []string{"route_map_clause"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "route_map"}
 		},
@@ -690,6 +714,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "site",
 		PutRequestType:   reflect.TypeOf(openapi.SitesPatchRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.SitesPatchRequest{}),
+		DependsOn:        []string{"lag", "service", "switchpoint"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "site"}
 		},
@@ -702,6 +727,24 @@ var resourceRegistry = map[string]ResourceConfig{
 			return c.SitesAPI.SitesGet(ctx).Execute()
 		},
 	},
+	"image_update_set": {
+		ResourceType:     "image_update_set",
+		PutRequestType:   reflect.TypeOf(openapi.ImageupdatesetsPatchRequest{}),
+		PatchRequestType: reflect.TypeOf(openapi.ImageupdatesetsPatchRequest{}),
+		// Endpoint set subrules can reference badges, bundles, pods and switchpoints
+		DependsOn: []string{"badge", "bundle", "pod", "switchpoint"},
+		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
+			return &GenericAPIClient{client: c, resourceType: "image_update_set"}
+		},
+		PutFunc: nil, // Image Update Sets only support PATCH
+		PatchFunc: func(c *openapi.APIClient, ctx context.Context, req interface{}) (*http.Response, error) {
+			return c.ImageUpdateSetsAPI.ImageupdatesetsPatch(ctx).ImageupdatesetsPatchRequest(*req.(*openapi.ImageupdatesetsPatchRequest)).Execute()
+		},
+		DeleteFunc: nil, // No DELETE operation
+		GetFunc: func(c *openapi.APIClient, ctx context.Context) (*http.Response, error) {
+			return c.ImageUpdateSetsAPI.ImageupdatesetsGet(ctx).Execute()
+		},
+	},
 	"pod": {
 		ResourceType:     "pod",
 		PutRequestType:   reflect.TypeOf(openapi.PodsPutRequest{}),
@@ -766,6 +809,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "diagnostics_profile",
 		PutRequestType:   reflect.TypeOf(openapi.DiagnosticsprofilesPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.DiagnosticsprofilesPutRequest{}),
+		DependsOn:        []string{"sflow_collector"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "diagnostics_profile"}
 		},
@@ -806,6 +850,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "pb_routing",
 		PutRequestType:   reflect.TypeOf(openapi.PolicybasedroutingPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.PolicybasedroutingPutRequest{}),
+		DependsOn:        []string{"pb_routing_acl"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "pb_routing"}
 		},
@@ -826,6 +871,7 @@ var resourceRegistry = map[string]ResourceConfig{
 		ResourceType:     "pb_routing_acl",
 		PutRequestType:   reflect.TypeOf(openapi.PolicybasedroutingaclPutRequest{}),
 		PatchRequestType: reflect.TypeOf(openapi.PolicybasedroutingaclPutRequest{}),
+		DependsOn:        []string{"acl", "ipv4_list", "ipv6_list"},
 		APIClientGetter: func(c *openapi.APIClient) ResourceAPIClient {
 			return &GenericAPIClient{client: c, resourceType: "pb_routing_acl"}
 		},
@@ -61,7 +61,7 @@ func ExecuteResourceOperationWithOptions(
 
 	notifyFunc()
 
-	if err := bulkOpsMgr.WaitForOperation(ctx, operationID, OperationTimeout); err != nil {
+	if err := bulkOpsMgr.WaitForOperation(ctx, operationID, bulkOpsMgr.OperationTimeout()); err != nil {
 		diagnostics.Append(
 			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to %s %s %s", operationType, resourceType, resourceName))...,
 		)
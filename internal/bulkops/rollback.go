@@ -0,0 +1,373 @@
+package bulkops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ================================================================================================
+// ROLLBACK JOURNAL
+// ================================================================================================
+//
+// With RollbackOnFailure, every successful batch of a run records how to undo it:
+// - created objects are deleted again
+// - updated objects are patched back to the values read with GetFunc before the update, and
+//   entries the update added to indexed lists are removed again
+// - deleted objects are created again from the values read before the deletion
+// When a batch of the run fails, the journal is replayed in reverse, which is the reverse
+// dependency order the batches were sent in. The operations of a successful batch only
+// complete once the run is over, so Terraform does not record changes that are rolled back.
+// The journal only covers one run: Terraform queues a resource once the resources it references
+// have completed, so those were sent by an earlier run and are not undone.
+// ================================================================================================
+
+// rollbackEntry undoes one successful batch.
+type rollbackEntry struct {
+	resourceType  string
+	headers       map[string]string
+	operationType string                 // operation of the batch: PUT, PATCH or DELETE
+	names         []string               // resources of the batch
+	priorValues   map[string]interface{} // values before a PATCH or DELETE, by resource name
+	operationIDs  []string               // operations held until the run completes
+}
+
+// rollbackJournal records the successful batches of one run in the order they completed.
+type rollbackJournal struct {
+	mu          sync.Mutex
+	entries     []rollbackEntry
+	priorValues map[string]map[string]interface{} // by journalKey, then resource name
+}
+
+func newRollbackJournal() *rollbackJournal {
+	return &rollbackJournal{priorValues: make(map[string]map[string]interface{})}
+}
+
+// journalKey identifies the values captured for the batches of a resource type and operation.
+func journalKey(resourceType, operationType string, headers map[string]string) string {
+	return fmt.Sprintf("%s:%s:%v", resourceType, operationType, headers)
+}
+
+// activeJournal returns the journal of the run in progress, or nil without rollback.
+func (m *Manager) activeJournal() *rollbackJournal {
+	m.operationMutex.Lock()
+	defer m.operationMutex.Unlock()
+	return m.journal
+}
+
+// capturePriorValues reads the current values of the resources a PATCH or DELETE is about
+// to change, so the change can be undone. Resources that do not exist are not captured.
+func (m *Manager) capturePriorValues(ctx context.Context, journal *rollbackJournal, config BulkOperationConfig, resourceNames []string) error {
+	resourceConfig, exists := resourceRegistry[config.ResourceType]
+	if !exists {
+		return fmt.Errorf("unknown resource type: %s", config.ResourceType)
+	}
+
+	fetchCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+
+	resourceData, err := m.fetchResourceData(fetchCtx, ctx, resourceConfig, config.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to read %s before %s for rollback: %w", config.ResourceType, config.OperationType, err)
+	}
+
+	prior := make(map[string]interface{}, len(resourceNames))
+	for _, name := range resourceNames {
+		if value, ok := resourceData[name]; ok {
+			prior[name] = value
+		}
+	}
+
+	journal.mu.Lock()
+	journal.priorValues[journalKey(config.ResourceType, config.OperationType, config.Headers)] = prior
+	journal.mu.Unlock()
+	return nil
+}
+
+// record adds a successful batch to the journal.
+func (j *rollbackJournal) record(config BulkOperationConfig, resourceNames []string, operationIDs []string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry := rollbackEntry{
+		resourceType:  config.ResourceType,
+		headers:       config.Headers,
+		operationType: config.OperationType,
+		names:         append([]string(nil), resourceNames...),
+		operationIDs:  operationIDs,
+	}
+	if prior, ok := j.priorValues[journalKey(config.ResourceType, config.OperationType, config.Headers)]; ok {
+		entry.priorValues = make(map[string]interface{}, len(resourceNames))
+		for _, name := range resourceNames {
+			if value, ok := prior[name]; ok {
+				entry.priorValues[name] = value
+			}
+		}
+	}
+	j.entries = append(j.entries, entry)
+}
+
+// executeWithRollback runs the queued operations in dependency order and, with
+// RollbackOnFailure, undoes every successful batch of the run if one of them fails.
+func (m *Manager) executeWithRollback(ctx context.Context, mode string) (diag.Diagnostics, bool) {
+	if !m.settings.RollbackOnFailure {
+		return m.executeDependencyLevels(ctx, mode)
+	}
+
+	journal := newRollbackJournal()
+	m.operationMutex.Lock()
+	m.journal = journal
+	m.operationMutex.Unlock()
+
+	diagnostics, operationsPerformed := m.executeDependencyLevels(ctx, mode)
+
+	m.operationMutex.Lock()
+	m.journal = nil
+	m.operationMutex.Unlock()
+
+	undone := make(map[int]bool)
+	if diagnostics.HasError() && len(journal.entries) > 0 {
+		var rollbackDiags diag.Diagnostics
+		undone, rollbackDiags = m.rollback(ctx, journal)
+		diagnostics.Append(rollbackDiags...)
+	}
+	m.completeJournal(journal, undone)
+
+	return diagnostics, operationsPerformed
+}
+
+// rollback replays the journal in reverse and returns the entries that were undone. An entry
+// that cannot be undone is reported and the remaining entries are still rolled back.
+func (m *Manager) rollback(ctx context.Context, journal *rollbackJournal) (map[int]bool, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	undone := make(map[int]bool)
+
+	tflog.Warn(ctx, fmt.Sprintf("[BULK-OPS] Rolling back %d completed batches", len(journal.entries)))
+
+	var failures []string
+	for i := len(journal.entries) - 1; i >= 0; i-- {
+		entry := journal.entries[i]
+		if err := m.undo(ctx, entry); err != nil {
+			tflog.Error(ctx, fmt.Sprintf("[BULK-OPS] Failed to roll back %s %s", entry.resourceType, entry.operationType), map[string]interface{}{
+				"error": err.Error(),
+				"names": entry.names,
+			})
+			failures = append(failures, fmt.Sprintf("%s %s of %s: %v", entry.resourceType, entry.operationType, strings.Join(entry.names, ", "), err))
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("[BULK-OPS] Rolled back %s %s for %d resource(s)", entry.resourceType, entry.operationType, len(entry.names)))
+		undone[i] = true
+	}
+
+	if len(failures) > 0 {
+		diagnostics.AddError(
+			"Rollback Incomplete",
+			fmt.Sprintf("A bulk operation failed and the changes made before it were rolled back, except for:\n%s\nThese changes remain on the controller.", strings.Join(failures, "\n")),
+		)
+	}
+	return undone, diagnostics
+}
+
+// undo sends the request that reverts one journal entry.
+func (m *Manager) undo(ctx context.Context, entry rollbackEntry) error {
+	config, exists := resourceRegistry[entry.resourceType]
+	if !exists {
+		return fmt.Errorf("unknown resource type: %s", entry.resourceType)
+	}
+
+	apiCtx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+
+	var resp *http.Response
+	var err error
+	switch entry.operationType {
+	case "PUT":
+		resp, err = m.sendUndoDelete(apiCtx, config, entry)
+	case "PATCH":
+		if len(entry.priorValues) == 0 {
+			return nil
+		}
+		request, buildErr := undoRequest(config.PatchRequestType, m.undoPatchValues(ctx, apiCtx, config, entry))
+		if buildErr != nil {
+			return buildErr
+		}
+		if entry.headers != nil && config.HeaderPatchFunc != nil {
+			resp, err = config.HeaderPatchFunc(m.client, apiCtx, request, entry.headers)
+		} else if config.PatchFunc != nil {
+			resp, err = config.PatchFunc(m.client, apiCtx, request)
+		} else {
+			return fmt.Errorf("PATCH is not supported for %s", entry.resourceType)
+		}
+	case "DELETE":
+		if len(entry.priorValues) == 0 {
+			return nil
+		}
+		request, buildErr := undoRequest(config.PutRequestType, entry.priorValues)
+		if buildErr != nil {
+			return buildErr
+		}
+		if entry.headers != nil && config.HeaderPutFunc != nil {
+			resp, err = config.HeaderPutFunc(m.client, apiCtx, request, entry.headers)
+		} else if config.PutFunc != nil {
+			resp, err = config.PutFunc(m.client, apiCtx, request)
+		} else {
+			return fmt.Errorf("PUT is not supported for %s", entry.resourceType)
+		}
+	}
+
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+// undoPatchValues returns the values that revert a PATCH entry: the prior values, with a
+// deletion marker for every indexed list entry the PATCH added, as patching the prior values
+// back would keep them. When the current values can't be read, only the prior values are used.
+func (m *Manager) undoPatchValues(ctx, apiCtx context.Context, config ResourceConfig, entry rollbackEntry) map[string]interface{} {
+	current, err := m.fetchResourceData(apiCtx, ctx, config, entry.headers)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("[BULK-OPS] Failed to read %s for rollback, list entries added by the PATCH are kept", entry.resourceType), map[string]interface{}{
+			"error": err.Error(),
+		})
+		return entry.priorValues
+	}
+
+	values := make(map[string]interface{}, len(entry.priorValues))
+	for name, prior := range entry.priorValues {
+		values[name] = withRemovedListEntries(prior, current[name])
+	}
+	return values
+}
+
+// withRemovedListEntries returns prior with an {"index": n} deletion marker added to its lists
+// of indexed entries for every entry that only current has, in nested objects as well.
+func withRemovedListEntries(prior, current interface{}) interface{} {
+	priorObject, ok := prior.(map[string]interface{})
+	currentObject, currentOk := current.(map[string]interface{})
+	if !ok || !currentOk {
+		return prior
+	}
+
+	result := make(map[string]interface{}, len(priorObject))
+	for key, value := range priorObject {
+		result[key] = value
+	}
+	for key, currentValue := range currentObject {
+		switch currentValue := currentValue.(type) {
+		case map[string]interface{}:
+			if priorValue, ok := priorObject[key]; ok {
+				result[key] = withRemovedListEntries(priorValue, currentValue)
+			}
+		case []interface{}:
+			priorList, _ := priorObject[key].([]interface{})
+			known := make(map[float64]bool, len(priorList))
+			for _, item := range priorList {
+				if index, ok := entryIndex(item); ok {
+					known[index] = true
+				}
+			}
+
+			list := append([]interface{}(nil), priorList...)
+			for _, item := range currentValue {
+				if index, ok := entryIndex(item); ok && !known[index] {
+					list = append(list, map[string]interface{}{"index": index})
+				}
+			}
+			if len(list) > len(priorList) {
+				result[key] = list
+			}
+		}
+	}
+	return result
+}
+
+// entryIndex returns the index of an entry of an indexed list, as decoded from JSON.
+func entryIndex(item interface{}) (float64, bool) {
+	entry, ok := item.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	index, ok := entry["index"].(float64)
+	return index, ok
+}
+
+// sendUndoDelete deletes the objects created by a PUT batch, in requests of at most
+// MaxDeleteBatchSize names.
+func (m *Manager) sendUndoDelete(ctx context.Context, config ResourceConfig, entry rollbackEntry) (*http.Response, error) {
+	for start := 0; start < len(entry.names); start += m.settings.MaxDeleteBatchSize {
+		end := start + m.settings.MaxDeleteBatchSize
+		if end > len(entry.names) {
+			end = len(entry.names)
+		}
+
+		var resp *http.Response
+		var err error
+		if entry.headers != nil && config.HeaderDeleteFunc != nil {
+			resp, err = config.HeaderDeleteFunc(m.client, ctx, entry.names[start:end], entry.headers)
+		} else if config.DeleteFunc != nil {
+			resp, err = config.DeleteFunc(m.client, ctx, entry.names[start:end])
+		} else {
+			return nil, fmt.Errorf("DELETE is not supported for %s", entry.resourceType)
+		}
+		if err != nil {
+			return resp, err
+		}
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+	}
+	return nil, nil
+}
+
+// undoRequest builds a PUT or PATCH request of requestType carrying values by resource name.
+// The request types wrap a single map of resources, under the JSON key of their first field.
+func undoRequest(requestType reflect.Type, values map[string]interface{}) (interface{}, error) {
+	if requestType == nil || requestType.Kind() != reflect.Struct || requestType.NumField() == 0 {
+		return nil, fmt.Errorf("cannot build a request of type %v", requestType)
+	}
+	key := strings.Split(requestType.Field(0).Tag.Get("json"), ",")[0]
+
+	data, err := json.Marshal(map[string]interface{}{key: values})
+	if err != nil {
+		return nil, err
+	}
+	request := reflect.New(requestType).Interface()
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("failed to build %v from prior values: %w", requestType, err)
+	}
+	return request, nil
+}
+
+// completeJournal completes the operations held by the journal. Operations whose batch was
+// rolled back fail, so Terraform does not record them; the others succeed.
+func (m *Manager) completeJournal(journal *rollbackJournal, undone map[int]bool) {
+	m.operationMutex.Lock()
+	var idsToClose []string
+	for i, entry := range journal.entries {
+		for _, opID := range entry.operationIDs {
+			if undone[i] {
+				err := fmt.Errorf("%s %s was rolled back because a later bulk operation failed", entry.resourceType, entry.operationType)
+				if op, ok := m.pendingOperations[opID]; ok {
+					op.Status = OperationFailed
+					op.Error = err
+				}
+				m.operationErrors[opID] = err
+				m.operationResults[opID] = false
+			}
+			idsToClose = append(idsToClose, opID)
+		}
+	}
+	m.operationMutex.Unlock()
+
+	for _, opID := range idsToClose {
+		m.safeCloseChannel(opID, false)
+	}
+}
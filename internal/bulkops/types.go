@@ -31,6 +31,7 @@ type Operation struct {
 	Status             OperationStatus // Current status of the operation
 	Error              error           // Error if operation failed
 	ExecutionStartTime time.Time       // When the API call actually started executing
+	RequestEndTime     time.Time       // When the API call returned, zero while it is in flight
 }
 
 // ResourceExistenceCheck provides configuration for checking if resources already exist.
@@ -50,6 +51,7 @@ type BulkOperationConfig struct {
 	ExecuteRequest    func(ctx context.Context, request interface{}) (*http.Response, error)                                                                 // Executes API request
 	ProcessResponse   func(ctx context.Context, resp *http.Response) error                                                                                   // Processes API response
 	UpdateRecentOps   func()                                                                                                                                 // Updates recent operation tracking
+	Headers           map[string]string                                                                                                                      // Header params of header-split batches
 }
 
 // ResourceOperations holds all operation data for a single resource type.
@@ -85,6 +87,10 @@ type ResourceConfig struct {
 	DeleteFunc       func(*openapi.APIClient, context.Context, []string) (*http.Response, error)    // Direct DELETE API call
 	GetFunc          func(*openapi.APIClient, context.Context) (*http.Response, error)              // Direct GET API call
 
+	// DependsOn lists the resource types that this resource's *_ref_type_ fields can point at.
+	// It drives execution order: PUT/PATCH run after these types, DELETE runs before them.
+	DependsOn []string
+
 	// HeaderSplitKey specifies which header param to use for splitting operations into separate batches
 	// Example: "ip_version" for ACLs (splits into IPv4/IPv6 batches)
 	HeaderSplitKey string
@@ -124,12 +130,15 @@ const (
 
 // Configuration constants for bulk operation timing and limits.
 const (
-	MaxBatchSize       = 1000              // Maximum number of resources per batch
-	MaxDeleteBatchSize = 100               // Maximum resources per DELETE batch to avoid URL length limits
-	OperationTimeout   = 300 * time.Second // Timeout for individual API operations
+	MaxBatchSize            = 1000              // Default maximum number of resources per PUT or PATCH batch
+	MaxDeleteBatchSize      = 100               // Default maximum resources per DELETE batch to avoid URL length limits
+	DefaultOperationTimeout = 300 * time.Second // Timeout for individual API operations unless configured otherwise
+
+	DefaultMaxParallelBatches = 1 // Independent resource types are sent one at a time unless configured otherwise
 )
 
-// Timing variables (configurable for CI/testing).
+// Timing variables (configurable for CI/testing). They are the defaults of Settings, which the
+// provider's bulk block overrides per configuration.
 var (
 	DefaultBatchDelay      = parseDuration("VERITY_DEFAULT_BATCH_DELAY", 2*time.Second)
 	BatchCollectionWindow  = parseDuration("VERITY_BATCH_COLLECTION_WINDOW", 2000*time.Millisecond)
@@ -138,6 +147,65 @@ var (
 	DebounceDelay          = parseDuration("VERITY_DEBOUNCE_DELAY", 15*time.Second)
 )
 
+// Settings holds the batching limits, timing and failure handling of a Manager.
+type Settings struct {
+	// BatchCollectionWindow is how long to wait after the last queued operation before sending a batch
+	BatchCollectionWindow time.Duration
+	// MaxBatchDelay is how long a stage barrier waits for operations of concurrent resources
+	MaxBatchDelay time.Duration
+	// ResponseProcessorDelay is how long to wait for server assigned values before reading them back
+	ResponseProcessorDelay time.Duration
+	// DebounceDelay is how long the provider waits for further operations before executing the queue
+	DebounceDelay time.Duration
+	// AdaptiveDebounce shortens DebounceDelay when operations stop arriving, see AdaptiveDebounceDelay
+	AdaptiveDebounce bool
+
+	MaxBatchSize       int
+	MaxDeleteBatchSize int
+	// MaxParallelBatches limits how many resource types without a dependency between them
+	// are sent to the API at the same time
+	MaxParallelBatches int
+
+	// ContinueOnError keeps executing the resource types that do not depend on a failed batch,
+	// instead of aborting every remaining operation
+	ContinueOnError bool
+	// RollbackOnFailure undoes the successful batches of a run when one of its batches fails
+	RollbackOnFailure bool
+}
+
+// DefaultSettings returns the settings used unless the provider configures others.
+func DefaultSettings() Settings {
+	return Settings{
+		BatchCollectionWindow:  BatchCollectionWindow,
+		MaxBatchDelay:          MaxBatchDelay,
+		ResponseProcessorDelay: ResponseProcessorDelay,
+		DebounceDelay:          DebounceDelay,
+		MaxBatchSize:           MaxBatchSize,
+		MaxDeleteBatchSize:     MaxDeleteBatchSize,
+		MaxParallelBatches:     DefaultMaxParallelBatches,
+	}
+}
+
+// AdaptiveDebounceDelay returns the debounce delay after an operation was queued, given the
+// longest gap between operations queued so far in the same burst. Terraform queues the
+// resources it is working on in quick succession, so once no operation arrived for twice the
+// longest gap it has nothing left to send. The delay never drops below BatchCollectionWindow
+// and never exceeds DebounceDelay.
+func (s Settings) AdaptiveDebounceDelay(longestGap time.Duration) time.Duration {
+	if !s.AdaptiveDebounce {
+		return s.DebounceDelay
+	}
+
+	delay := 2 * longestGap
+	if delay < s.BatchCollectionWindow {
+		delay = s.BatchCollectionWindow
+	}
+	if delay > s.DebounceDelay {
+		delay = s.DebounceDelay
+	}
+	return delay
+}
+
 // parseDuration reads a Go duration string from the environment variable.
 // Returns the default value if the variable is unset or unparseable.
 func parseDuration(envVar string, defaultVal time.Duration) time.Duration {
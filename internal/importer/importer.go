@@ -148,6 +148,9 @@ var importerRegistry = map[string]struct {
 	"sites": {apiCaller: func(ctx context.Context, client *openapi.APIClient) (*http.Response, error) {
 		return client.SitesAPI.SitesGet(ctx).Execute()
 	}},
+	"imageupdatesets": {apiCaller: func(ctx context.Context, client *openapi.APIClient) (*http.Response, error) {
+		return client.ImageUpdateSetsAPI.ImageupdatesetsGet(ctx).Execute()
+	}},
 	"pods": {apiCaller: func(ctx context.Context, client *openapi.APIClient) (*http.Response, error) {
 		return client.PodsAPI.PodsGet(ctx).Execute()
 	}},
@@ -213,6 +216,7 @@ var terraformTypeToResourceKey = map[string]string{
 	"verity_route_map":                "route_map",
 	"verity_sfp_breakout":             "sfp_breakout",
 	"verity_site":                     "site",
+	"verity_image_update_set":         "image_update_set",
 	"verity_pod":                      "pod",
 	"verity_port_acl":                 "port_acl",
 	"verity_grouping_rule":            "grouping_rule",
@@ -523,6 +527,14 @@ var resourceConfigs = map[string]ResourceConfig{
 		NestedBlockFields:            map[string]bool{"islands": true, "pairs": true, "system_graphs": true},
 		ObjectPropsNestedBlockFields: map[string]bool{"system_graphs": true},
 	},
+	"image_update_set": {
+		ResourceType:              "image_update_set",
+		StageName:                 "image_update_set_stage",
+		HeaderNameLineFormat:      "    name = \"%s\"\n",
+		HeaderDependsOnLineFormat: "    depends_on = [verity_operation_stage.%s]\n",
+		ObjectPropsHandler:        universalObjectPropsHandler,
+		NestedBlockFields:         map[string]bool{"section": true, "section_else": true, "section_pointless": true},
+	},
 	"pod": {
 		ResourceType:              "pod",
 		StageName:                 "pod_stage",
@@ -638,6 +650,7 @@ func (i *Importer) ImportAll(outputDir string) error {
 		{name: "routemaps", terraformResourceType: "verity_route_map", importer: func() (interface{}, error) { return i.importResource("routemaps") }},
 		{name: "sfpbreakouts", terraformResourceType: "verity_sfp_breakout", importer: func() (interface{}, error) { return i.importResource("sfpbreakouts") }},
 		{name: "sites", terraformResourceType: "verity_site", importer: func() (interface{}, error) { return i.importResource("sites") }},
+		{name: "imageupdatesets", terraformResourceType: "verity_image_update_set", importer: func() (interface{}, error) { return i.importResource("imageupdatesets") }},
 		{name: "pods", terraformResourceType: "verity_pod", importer: func() (interface{}, error) { return i.importResource("pods") }},
 		{name: "portacls", terraformResourceType: "verity_port_acl", importer: func() (interface{}, error) { return i.importResource("portacls") }},
 		{name: "groupingrules", terraformResourceType: "verity_grouping_rule", importer: func() (interface{}, error) { return i.importResource("groupingrules") }},
@@ -1001,7 +1014,7 @@ func (i *Importer) generateStagesTF() (string, error) {
 		// 14. Device Voice Settings, 15. Authenticated Eth Ports, 16. Diagnostics Profiles,
 		// 17. Eth Port Settings, 18. Voice Port Profiles, 19. Device Settings, 20. Lags,
 		// 21. Bundles, 22. Badges, 23. Switchpoints, 24. Thresholds, 25. Grouping Rules,
-		// 26. Threshold Groups, 27. Sites, 28. Device Controllers
+		// 26. Threshold Groups, 27. Sites, 28. Image Update Sets, 29. Device Controllers
 		stageOrder = []StageDefinition{
 			{"ipv4_list_stage", "verity_ipv4_list", ""},
 			{"ipv6_list_stage", "verity_ipv6_list", "ipv4_list_stage"},
@@ -1030,7 +1043,8 @@ func (i *Importer) generateStagesTF() (string, error) {
 			{"grouping_rule_stage", "verity_grouping_rule", "threshold_stage"},
 			{"threshold_group_stage", "verity_threshold_group", "grouping_rule_stage"},
 			{"site_stage", "verity_site", "threshold_group_stage"},
-			{"device_controller_stage", "verity_device_controller", "site_stage"},
+			{"image_update_set_stage", "verity_image_update_set", "site_stage"},
+			{"device_controller_stage", "verity_device_controller", "image_update_set_stage"},
 		}
 	} else {
 		// DATACENTER mode staging order:
@@ -1042,7 +1056,7 @@ func (i *Importer) generateStagesTF() (string, error) {
 		// 22. Gateways, 23. Lags, 24. Eth Port Settings, 25. Diagnostics Profiles,
 		// 26. Gateway Profiles,  27. Device Settings, 28. Diagnostics Port Profiles, 29. Bundles, 30. Pods,
 		// 31. Badges, 32. Spine Planes, 33. Switchpoints, 34. Thresholds, 35. Grouping Rules,
-		// 36. Threshold Groups, 37. Sites, 38. Device Controllers
+		// 36. Threshold Groups, 37. Sites, 38. Image Update Sets, 39. Device Controllers
 
 		stageOrder = []StageDefinition{
 			{"sfp_breakout_stage", "verity_sfp_breakout", ""},
@@ -1082,7 +1096,8 @@ func (i *Importer) generateStagesTF() (string, error) {
 			{"grouping_rule_stage", "verity_grouping_rule", "threshold_stage"},
 			{"threshold_group_stage", "verity_threshold_group", "grouping_rule_stage"},
 			{"site_stage", "verity_site", "threshold_group_stage"},
-			{"device_controller_stage", "verity_device_controller", "site_stage"},
+			{"image_update_set_stage", "verity_image_update_set", "site_stage"},
+			{"device_controller_stage", "verity_device_controller", "image_update_set_stage"},
 		}
 	}
 
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// execCredentialTimeout bounds a single run of the credential helper
+const execCredentialTimeout = 60 * time.Second
+
+type execCredentialModel struct {
+	Command types.String `tfsdk:"command"`
+	Args    types.List   `tfsdk:"args"`
+	Env     types.Map    `tfsdk:"env"`
+}
+
+// execCredential is an external command that prints an API token as JSON on stdout:
+//
+//	{"token": "...", "expires_in": 3600}
+//
+// expires_in is optional and given in seconds.
+type execCredential struct {
+	command string
+	args    []string
+	env     map[string]string
+}
+
+type execCredentialOutput struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// run executes the credential helper and returns the token and its lifetime.
+func (e *execCredential) run(ctx context.Context) (string, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, execCredentialTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.command, e.args...)
+	cmd.Env = os.Environ()
+	for name, value := range e.env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", 0, fmt.Errorf("credential helper %s failed: %v: %s", e.command, err, msg)
+		}
+		return "", 0, fmt.Errorf("credential helper %s failed: %v", e.command, err)
+	}
+
+	var output execCredentialOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return "", 0, fmt.Errorf("credential helper %s did not print valid JSON: %v", e.command, err)
+	}
+	if output.Token == "" {
+		return "", 0, fmt.Errorf("credential helper %s did not return a token", e.command)
+	}
+
+	lifetime := defaultTokenLifetime
+	if output.ExpiresIn > 0 {
+		lifetime = time.Duration(output.ExpiresIn) * time.Second
+	}
+	return output.Token, lifetime, nil
+}
+
+// newExecCredential converts the exec provider attribute, falling back to the
+// TF_VAR_exec_command environment variable for a helper without arguments.
+func newExecCredential(ctx context.Context, model *execCredentialModel) (*execCredential, error) {
+	if model == nil {
+		if command := os.Getenv("TF_VAR_exec_command"); command != "" {
+			return &execCredential{command: command}, nil
+		}
+		return nil, nil
+	}
+
+	e := &execCredential{command: model.Command.ValueString()}
+	if e.command == "" {
+		return nil, fmt.Errorf("exec.command must not be empty")
+	}
+	if !model.Args.IsNull() {
+		if diags := model.Args.ElementsAs(ctx, &e.args, false); diags.HasError() {
+			return nil, fmt.Errorf("invalid exec.args")
+		}
+	}
+	if !model.Env.IsNull() {
+		if diags := model.Env.ElementsAs(ctx, &e.env, false); diags.HasError() {
+			return nil, fmt.Errorf("invalid exec.env")
+		}
+	}
+	return e, nil
+}
@@ -198,6 +198,7 @@ func createImportBlocks(ctx context.Context, dirPath string) (string, error) {
 		"verity_switchpoint":              {},
 		"verity_device_controller":        {},
 		"verity_site":                     {},
+		"verity_image_update_set":         {},
 		"verity_tenant":                   {},
 		"verity_gateway_profile":          {},
 		"verity_gateway":                  {},
@@ -0,0 +1,651 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/openapi"
+)
+
+// objectDataSourceDefinitions lists the resource types that get read-only lookups. Each entry
+// yields a singular data source named after the resource (looked up by name) and a plural data
+// source that lists and filters all objects of the type. Both reuse the resource schema and its
+// populate*State function, so the data sources follow the resources automatically.
+var objectDataSourceDefinitions = []objectDataSourceDefinition{
+	newObjectDataSourceConfig("tenants", "tenant", NewVerityTenantResource, populateTenantState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.TenantsAPI.TenantsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("gateways", "gateway", NewVerityGatewayResource, populateGatewayState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.GatewaysAPI.GatewaysGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("services", "service", NewVerityServiceResource, populateServiceState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ServicesAPI.ServicesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("eth_port_profiles", "eth_port_profile", NewVerityEthPortProfileResource, populateEthPortProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.EthPortProfilesAPI.EthportprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("eth_port_settings_list", "eth_port_settings", NewVerityEthPortSettingsResource, populateEthPortSettingsState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.EthPortSettingsAPI.EthportsettingsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("bundles", "bundle", NewVerityBundleResource, populateBundleState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.BundlesAPI.BundlesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("lags", "lag", NewVerityLagResource, populateLagState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.LAGsAPI.LagsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("gateway_profiles", "gateway_profile", NewVerityGatewayProfileResource, populateGatewayProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.GatewayProfilesAPI.GatewayprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("acls_v4", "acls_ipv4", NewVerityACLV4Resource, populateACLState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ACLsAPI.AclsGet(ctx).IpVersion("4").Execute()
+		}),
+	newObjectDataSourceConfig("acls_v6", "acls_ipv6", NewVerityACLV6Resource, populateACLState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ACLsAPI.AclsGet(ctx).IpVersion("6").Execute()
+		}),
+	newObjectDataSourceConfig("badges", "badge", NewVerityBadgeResource, populateBadgeState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.BadgesAPI.BadgesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("authenticated_eth_ports", "authenticated_eth_port", NewVerityAuthenticatedEthPortResource, populateAuthenticatedEthPortState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.AuthenticatedEthPortsAPI.AuthenticatedethportsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("device_voice_settings_list", "device_voice_settings", NewVerityDeviceVoiceSettingsResource, populateDeviceVoiceSettingsState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.DeviceVoiceSettingsAPI.DevicevoicesettingsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("packet_brokers", "packet_broker", NewVerityPacketBrokerResource, populatePacketBrokerState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PacketBrokerAPI.PacketbrokerGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("packet_queues", "packet_queue", NewVerityPacketQueueResource, populatePacketQueueState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PacketQueuesAPI.PacketqueuesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("service_port_profiles", "service_port_profile", NewVerityServicePortProfileResource, populateServicePortProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ServicePortProfilesAPI.ServiceportprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("voice_port_profiles", "voice_port_profile", NewVerityVoicePortProfileResource, populateVoicePortProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.VoicePortProfilesAPI.VoiceportprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("switchpoints", "switchpoint", NewVeritySwitchpointResource, populateSwitchpointState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.SwitchpointsAPI.SwitchpointsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("device_controllers", "device_controller", NewVerityDeviceControllerResource, populateDeviceControllerState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.DeviceControllersAPI.DevicecontrollersGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("as_path_access_lists", "as_path_access_list", NewVerityAsPathAccessListResource, populateAsPathAccessListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ASPathAccessListsAPI.AspathaccesslistsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("community_lists", "community_list", NewVerityCommunityListResource, populateCommunityListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.CommunityListsAPI.CommunitylistsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("device_settings_list", "device_settings", NewVerityDeviceSettingsResource, populateDeviceSettingsState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.DeviceSettingsAPI.DevicesettingsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("extended_community_lists", "extended_community_list", NewVerityExtendedCommunityListResource, populateExtendedCommunityListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ExtendedCommunityListsAPI.ExtendedcommunitylistsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("ipv4_lists", "ipv4_list", NewVerityIpv4ListResource, populateIpv4ListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.IPv4ListFiltersAPI.Ipv4listsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("ipv4_prefix_lists", "ipv4_prefix_list", NewVerityIpv4PrefixListResource, populateIpv4PrefixListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.IPv4PrefixListsAPI.Ipv4prefixlistsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("ipv6_lists", "ipv6_list", NewVerityIpv6ListResource, populateIpv6ListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.IPv6ListFiltersAPI.Ipv6listsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("ipv6_prefix_lists", "ipv6_prefix_list", NewVerityIpv6PrefixListResource, populateIpv6PrefixListState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.IPv6PrefixListsAPI.Ipv6prefixlistsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("route_map_clauses", "route_map_clause", NewVerityRouteMapClauseResource, populateRouteMapClauseState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.RouteMapClausesAPI.RoutemapclausesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("route_maps", "route_map", NewVerityRouteMapResource, populateRouteMapState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.RouteMapsAPI.RoutemapsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("sfp_breakouts", "sfp_breakout", NewVeritySfpBreakoutResource, populateSfpBreakoutState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.SFPBreakoutsAPI.SfpbreakoutsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("sites", "site", NewVeritySiteResource, populateSiteState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.SitesAPI.SitesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("image_update_sets", "image_update_set", NewVerityImageUpdateSetResource, populateImageUpdateSetState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ImageUpdateSetsAPI.ImageupdatesetsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("pods", "pod", NewVerityPodResource, populatePodState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PodsAPI.PodsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("port_acls", "port_acl", NewVerityPortAclResource, populatePortAclState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PortACLsAPI.PortaclsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("sflow_collectors", "sflow_collector", NewVeritySflowCollectorResource, populateSflowCollectorState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.SFlowCollectorsAPI.SflowcollectorsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("diagnostics_profiles", "diagnostics_profile", NewVerityDiagnosticsProfileResource, populateDiagnosticsProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.DiagnosticsProfilesAPI.DiagnosticsprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("diagnostics_port_profiles", "diagnostics_port_profile", NewVerityDiagnosticsPortProfileResource, populateDiagnosticsPortProfileState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.DiagnosticsPortProfilesAPI.DiagnosticsportprofilesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("pb_routings", "pb_routing", NewVerityPBRoutingResource, populatePBRoutingState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PBRoutingAPI.PolicybasedroutingGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("pb_routing_acls", "pb_routing_acl", NewVerityPBRoutingACLResource, populatePBRoutingACLState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.PBRoutingACLAPI.PolicybasedroutingaclGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("spine_planes", "spine_plane", NewVeritySpinePlaneResource, populateSpinePlaneState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.SpinePlanesAPI.SpineplanesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("grouping_rules", "grouping_rule", NewVerityGroupingRuleResource, populateGroupingRuleState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.GroupingRulesAPI.GroupingrulesGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("threshold_groups", "threshold_group", NewVerityThresholdGroupResource, populateThresholdGroupState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ThresholdGroupsAPI.ThresholdgroupsGet(ctx).Execute()
+		}),
+	newObjectDataSourceConfig("thresholds", "threshold", NewVerityThresholdResource, populateThresholdState,
+		func(ctx context.Context, c *openapi.APIClient) (*http.Response, error) {
+			return c.ThresholdsAPI.ThresholdsGet(ctx).Execute()
+		}),
+}
+
+// objectDataSources returns the constructors of all singular and plural object data sources.
+func objectDataSources() []func() datasource.DataSource {
+	dataSources := make([]func() datasource.DataSource, 0, 2*len(objectDataSourceDefinitions))
+	for _, definition := range objectDataSourceDefinitions {
+		dataSources = append(dataSources, definition.dataSources()...)
+	}
+	return dataSources
+}
+
+type objectDataSourceDefinition interface {
+	dataSources() []func() datasource.DataSource
+}
+
+// objectDataSourceConfig describes one resource type exposed as data sources. M is the
+// resource model filled by populate.
+type objectDataSourceConfig[M any] struct {
+	// pluralSuffix is appended to the provider type name to form the plural data source name
+	pluralSuffix string
+	// resourceKey is the internal resource type used to look up the JSON response key
+	resourceKey string
+	newResource func() resource.Resource
+	populate    func(context.Context, M, map[string]interface{}, string) M
+	fetch       func(context.Context, *openapi.APIClient) (*http.Response, error)
+}
+
+func newObjectDataSourceConfig[M any](
+	pluralSuffix, resourceKey string,
+	newResource func() resource.Resource,
+	populate func(context.Context, M, map[string]interface{}, string) M,
+	fetch func(context.Context, *openapi.APIClient) (*http.Response, error),
+) *objectDataSourceConfig[M] {
+	return &objectDataSourceConfig[M]{
+		pluralSuffix: pluralSuffix,
+		resourceKey:  resourceKey,
+		newResource:  newResource,
+		populate:     populate,
+		fetch:        fetch,
+	}
+}
+
+func (c *objectDataSourceConfig[M]) dataSources() []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		func() datasource.DataSource { return &verityObjectDataSource[M]{config: c} },
+		func() datasource.DataSource { return &verityObjectsDataSource[M]{config: c} },
+	}
+}
+
+// resourceTypeName returns the Terraform type name of the underlying resource, e.g. verity_tenant.
+func (c *objectDataSourceConfig[M]) resourceTypeName(ctx context.Context, providerTypeName string) string {
+	var resp resource.MetadataResponse
+	c.newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+	return resp.TypeName
+}
+
+// objectAttributes converts the resource schema into computed data source attributes. Nested
+// blocks become nested attributes, so the object can also be used as an element of the plural
+// data source's items list.
+func (c *objectDataSourceConfig[M]) objectAttributes(ctx context.Context) (map[string]schema.Attribute, diag.Diagnostics) {
+	var resp resource.SchemaResponse
+	c.newResource().Schema(ctx, resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+
+	attributes, err := dataSourceAttributesFromResource(resp.Schema.Attributes, resp.Schema.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Unsupported Resource Schema", err.Error())
+		return nil, resp.Diagnostics
+	}
+	return attributes, resp.Diagnostics
+}
+
+// listObjects fetches all objects of the resource type, keyed by their API name.
+func (c *objectDataSourceConfig[M]) listObjects(ctx context.Context, provCtx *providerContext) (map[string]map[string]interface{}, error) {
+	typeName := c.resourceTypeName(ctx, "verity")
+	if !utils.IsResourceCompatibleWithMode(typeName, provCtx.mode) {
+		return nil, fmt.Errorf("%s is not available in %s mode", typeName, provCtx.mode)
+	}
+
+	if err := ensureAuthenticated(ctx, provCtx); err != nil {
+		return nil, fmt.Errorf("error authenticating with API: %v", err)
+	}
+
+	jsonKey := utils.GetResourceJSONKey(c.resourceKey)
+	tflog.Debug(ctx, fmt.Sprintf("Fetching %s objects for data source lookup", typeName))
+
+	apiResp, err := c.fetch(ctx, provCtx.client)
+	if err != nil {
+		return nil, err
+	}
+	defer apiResp.Body.Close()
+
+	var result map[string]json.RawMessage
+	if err := json.NewDecoder(apiResp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %v", jsonKey, err)
+	}
+
+	objects := make(map[string]map[string]interface{})
+	if raw, ok := result[jsonKey]; ok && len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &objects); err != nil {
+			return nil, fmt.Errorf("failed to decode %s objects: %v", jsonKey, err)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Fetched %d %s objects", len(objects), typeName))
+	return objects, nil
+}
+
+// objectName returns the name of an API object, falling back to its key in the response.
+func objectName(apiName string, data map[string]interface{}) string {
+	if name, ok := data["name"].(string); ok && name != "" {
+		return name
+	}
+	return apiName
+}
+
+func dataSourceAttributesFromResource(attributes map[string]resourceschema.Attribute, blocks map[string]resourceschema.Block) (map[string]schema.Attribute, error) {
+	result := make(map[string]schema.Attribute, len(attributes)+len(blocks))
+
+	for name, attribute := range attributes {
+		switch a := attribute.(type) {
+		case resourceschema.StringAttribute:
+			result[name] = schema.StringAttribute{Description: a.Description, Sensitive: a.Sensitive, Computed: true}
+		case resourceschema.BoolAttribute:
+			result[name] = schema.BoolAttribute{Description: a.Description, Sensitive: a.Sensitive, Computed: true}
+		case resourceschema.Int64Attribute:
+			result[name] = schema.Int64Attribute{Description: a.Description, Sensitive: a.Sensitive, Computed: true}
+		case resourceschema.Float64Attribute:
+			result[name] = schema.Float64Attribute{Description: a.Description, Sensitive: a.Sensitive, Computed: true}
+		case resourceschema.NumberAttribute:
+			result[name] = schema.NumberAttribute{Description: a.Description, Sensitive: a.Sensitive, Computed: true}
+		case resourceschema.ListAttribute:
+			result[name] = schema.ListAttribute{Description: a.Description, Sensitive: a.Sensitive, ElementType: a.ElementType, Computed: true}
+		case resourceschema.SetAttribute:
+			result[name] = schema.SetAttribute{Description: a.Description, Sensitive: a.Sensitive, ElementType: a.ElementType, Computed: true}
+		case resourceschema.MapAttribute:
+			result[name] = schema.MapAttribute{Description: a.Description, Sensitive: a.Sensitive, ElementType: a.ElementType, Computed: true}
+		default:
+			return nil, fmt.Errorf("attribute %q has unsupported type %T", name, attribute)
+		}
+	}
+
+	for name, block := range blocks {
+		switch b := block.(type) {
+		case resourceschema.ListNestedBlock:
+			nested, err := dataSourceAttributesFromResource(b.NestedObject.Attributes, b.NestedObject.Blocks)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = schema.ListNestedAttribute{
+				Description:  b.Description,
+				NestedObject: schema.NestedAttributeObject{Attributes: nested},
+				Computed:     true,
+			}
+		case resourceschema.SetNestedBlock:
+			nested, err := dataSourceAttributesFromResource(b.NestedObject.Attributes, b.NestedObject.Blocks)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = schema.SetNestedAttribute{
+				Description:  b.Description,
+				NestedObject: schema.NestedAttributeObject{Attributes: nested},
+				Computed:     true,
+			}
+		case resourceschema.SingleNestedBlock:
+			nested, err := dataSourceAttributesFromResource(b.Attributes, b.Blocks)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = schema.SingleNestedAttribute{
+				Description: b.Description,
+				Attributes:  nested,
+				Computed:    true,
+			}
+		default:
+			return nil, fmt.Errorf("block %q has unsupported type %T", name, block)
+		}
+	}
+
+	return result, nil
+}
+
+var (
+	_ datasource.DataSource              = &verityObjectDataSource[verityTenantResourceModel]{}
+	_ datasource.DataSourceWithConfigure = &verityObjectDataSource[verityTenantResourceModel]{}
+	_ datasource.DataSource              = &verityObjectsDataSource[verityTenantResourceModel]{}
+	_ datasource.DataSourceWithConfigure = &verityObjectsDataSource[verityTenantResourceModel]{}
+)
+
+// verityObjectDataSource looks up a single object by name, e.g. data "verity_tenant".
+type verityObjectDataSource[M any] struct {
+	config  *objectDataSourceConfig[M]
+	provCtx *providerContext
+}
+
+func (d *verityObjectDataSource[M]) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = d.config.resourceTypeName(ctx, req.ProviderTypeName)
+}
+
+func (d *verityObjectDataSource[M]) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes, diags := d.config.objectAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes["name"] = schema.StringAttribute{
+		Description: "Name of the object to look up",
+		Required:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Looks up a single %s object by name", d.config.resourceTypeName(ctx, "verity")),
+		Attributes:  attributes,
+	}
+}
+
+func (d *verityObjectDataSource[M]) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provCtx = configureObjectDataSource(req, resp)
+}
+
+func (d *verityObjectDataSource[M]) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	objectName := name.ValueString()
+	typeName := d.config.resourceTypeName(ctx, "verity")
+
+	objects, err := d.config.listObjects(ctx, d.provCtx)
+	if err != nil {
+		resp.Diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to Read %s %s", typeName, objectName))...,
+		)
+		return
+	}
+
+	data, ok := objects[objectName]
+	if !ok {
+		var found bool
+		data, _, found = utils.FindResourceByAPIName(objects, objectName, func(obj map[string]interface{}) (string, bool) {
+			name, ok := obj["name"].(string)
+			return name, ok
+		})
+		if !found {
+			resp.Diagnostics.AddError(
+				"Object Not Found",
+				fmt.Sprintf("No %s object named %q exists", typeName, objectName),
+			)
+			return
+		}
+	}
+
+	var state M
+	state = d.config.populate(ctx, state, data, d.provCtx.mode)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configured name must be kept as is even if the API object omits it
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// verityObjectsDataSource lists the objects of a resource type, e.g. data "verity_tenants".
+type verityObjectsDataSource[M any] struct {
+	config  *objectDataSourceConfig[M]
+	provCtx *providerContext
+}
+
+type verityObjectsDataSourceModel struct {
+	Enable    types.Bool   `tfsdk:"enable"`
+	Group     types.String `tfsdk:"group"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Names     types.List   `tfsdk:"names"`
+	Items     types.List   `tfsdk:"items"`
+}
+
+func (d *verityObjectsDataSource[M]) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.config.pluralSuffix
+}
+
+func (d *verityObjectsDataSource[M]) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes, diags := d.config.objectAttributes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Lists %s objects, optionally filtered", d.config.resourceTypeName(ctx, "verity")),
+		Attributes: map[string]schema.Attribute{
+			"enable": schema.BoolAttribute{
+				Description: "Only return objects whose enable field matches this value",
+				Optional:    true,
+			},
+			"group": schema.StringAttribute{
+				Description: "Only return objects whose object_properties.group matches this value",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Only return objects whose name matches this regular expression",
+				Optional:    true,
+			},
+			"names": schema.ListAttribute{
+				Description: "Names of the matching objects, sorted",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"items": schema.ListNestedAttribute{
+				Description:  "Matching objects, in the same order as names",
+				Computed:     true,
+				NestedObject: schema.NestedAttributeObject{Attributes: attributes},
+			},
+		},
+	}
+}
+
+func (d *verityObjectsDataSource[M]) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.provCtx = configureObjectDataSource(req, resp)
+}
+
+func (d *verityObjectsDataSource[M]) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data verityObjectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				fmt.Sprintf("Error compiling name_regex: %s", err),
+			)
+			return
+		}
+		nameRegex = re
+	}
+
+	typeName := d.config.resourceTypeName(ctx, "verity")
+	objects, err := d.config.listObjects(ctx, d.provCtx)
+	if err != nil {
+		resp.Diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to List %s Objects", typeName))...,
+		)
+		return
+	}
+
+	matches := make(map[string]map[string]interface{})
+	for apiName, obj := range objects {
+		name := objectName(apiName, obj)
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		if !data.Enable.IsNull() && !data.Enable.IsUnknown() {
+			enable, ok := obj["enable"].(bool)
+			if !ok || enable != data.Enable.ValueBool() {
+				continue
+			}
+		}
+		if !data.Group.IsNull() && !data.Group.IsUnknown() {
+			props, _ := obj["object_properties"].(map[string]interface{})
+			group, ok := props["group"].(string)
+			if !ok || group != data.Group.ValueString() {
+				continue
+			}
+		}
+		matches[name] = obj
+	}
+
+	names := make([]string, 0, len(matches))
+	for name := range matches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	itemsAttr, diags := resp.State.Schema.AttributeAtPath(ctx, path.Root("items"))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	itemsType := itemsAttr.GetType().TerraformType(ctx).(tftypes.List)
+
+	// Each item is rendered through a state holding a single object so the resource model
+	// and its populate function can be reused unchanged
+	itemSchema := schema.Schema{Attributes: itemsAttr.(schema.ListNestedAttribute).NestedObject.Attributes}
+	elements := make([]tftypes.Value, 0, len(names))
+	for _, name := range names {
+		var state M
+		state = d.config.populate(ctx, state, matches[name], d.provCtx.mode)
+
+		item := tfsdk.State{
+			Schema: itemSchema,
+			Raw:    tftypes.NewValue(itemsType.ElementType, nil),
+		}
+		resp.Diagnostics.Append(item.Set(ctx, &state)...)
+		resp.Diagnostics.Append(item.SetAttribute(ctx, path.Root("name"), name)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elements = append(elements, item.Raw)
+	}
+
+	items, err := itemsAttr.GetType().ValueFromTerraform(ctx, tftypes.NewValue(itemsType, elements))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Items",
+			fmt.Sprintf("Error building %s items: %s", typeName, err),
+		)
+		return
+	}
+
+	namesValue, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Names = namesValue
+	data.Items = items.(types.List)
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching %s objects", len(names), typeName))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func configureObjectDataSource(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *providerContext {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	provCtx, ok := req.ProviderData.(*providerContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+
+	return provCtx
+}
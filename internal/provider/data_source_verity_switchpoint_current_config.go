@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-verity/internal/utils"
+)
+
+var (
+	_ datasource.DataSource              = &switchpointCurrentConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &switchpointCurrentConfigDataSource{}
+)
+
+func NewVeritySwitchpointCurrentConfigDataSource() datasource.DataSource {
+	return &switchpointCurrentConfigDataSource{}
+}
+
+type switchpointCurrentConfigDataSource struct {
+	provCtx *providerContext
+}
+
+type switchpointCurrentConfigDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	SwitchpointName types.String `tfsdk:"switchpoint_name"`
+	Config          types.String `tfsdk:"config"`
+	Sections        types.Map    `tfsdk:"sections"`
+}
+
+func (d *switchpointCurrentConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switchpoint_current_config"
+}
+
+func (d *switchpointCurrentConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for the configuration currently rendered for a switchpoint",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for this data source, the switchpoint name",
+				Computed:    true,
+			},
+			"switchpoint_name": schema.StringAttribute{
+				Description: "Name of the switchpoint",
+				Required:    true,
+			},
+			"config": schema.StringAttribute{
+				Description: "Rendered device configuration",
+				Computed:    true,
+			},
+			"sections": schema.MapAttribute{
+				Description: "Rendered device configuration split into top-level sections, keyed by the section header (for example \"interface Ethernet1\" or \"router bgp 65000\")",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *switchpointCurrentConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provCtx, ok := req.ProviderData.(*providerContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.provCtx = provCtx
+}
+
+func (d *switchpointCurrentConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data switchpointCurrentConfigDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := ensureAuthenticated(ctx, d.provCtx); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return
+	}
+
+	name := data.SwitchpointName.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Fetching current config of switchpoint %s", name))
+
+	apiResp, err := d.provCtx.client.SwitchpointsAPI.SwitchpointsCurrentconfigGet(ctx).SwitchpointName(name).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to Read Current Config of Switchpoint %s", name))...,
+		)
+		return
+	}
+	defer apiResp.Body.Close()
+
+	body, err := io.ReadAll(apiResp.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Current Config",
+			fmt.Sprintf("Error reading current config of switchpoint %s: %v", name, err),
+		)
+		return
+	}
+
+	config, sections := utils.ParseSwitchpointCurrentConfig(name, body)
+
+	sectionsValue, diags := types.MapValueFrom(ctx, types.StringType, sections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(name)
+	data.Config = types.StringValue(config)
+	data.Sections = sectionsValue
+
+	tflog.Debug(ctx, fmt.Sprintf("Current config of switchpoint %s has %d sections", name, len(sections)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// endpointProbeTimeout bounds a health probe of a single controller endpoint
+const endpointProbeTimeout = 10 * time.Second
+
+// parseEndpoint validates a controller URL given in uri or failover_uris and returns its
+// scheme and host. Any path is dropped, as the API is always served under /api.
+func parseEndpoint(value string) (*url.URL, error) {
+	parsed, err := url.Parse(strings.TrimRight(strings.TrimSpace(value), "/"))
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("%q must start with http:// or https://", value)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("missing host in %q", value)
+	}
+	return &url.URL{Scheme: parsed.Scheme, Host: parsed.Host}, nil
+}
+
+// failoverTransport sends API requests to the active endpoint of a controller pair. The API
+// client always addresses the primary uri, so cookies and the work directory stay tied to
+// it; the transport rewrites each request to the endpoint that is currently healthy.
+//
+// When a request fails to connect, the other endpoints are probed in order and the first
+// one that answers becomes active. Its tokens are not shared with the failed controller, so
+// onFailover clears the token and the next request authenticates again.
+type failoverTransport struct {
+	base       http.RoundTripper
+	endpoints  []*url.URL
+	onFailover func()
+	logCtx     context.Context
+
+	mu     sync.Mutex
+	active int
+}
+
+func newFailoverTransport(ctx context.Context, base http.RoundTripper, endpoints []*url.URL, onFailover func()) *failoverTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &failoverTransport{
+		base:       base,
+		endpoints:  endpoints,
+		onFailover: onFailover,
+		logCtx:     ctx,
+	}
+}
+
+// activeEndpoint returns the index and URL of the endpoint requests are sent to.
+func (t *failoverTransport) activeEndpoint() (int, *url.URL) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.active, t.endpoints[t.active]
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a body without GetBody has been consumed by the first attempt and cannot be sent again
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	index, endpoint := t.activeEndpoint()
+	resp, err := t.base.RoundTrip(rewriteEndpoint(req, endpoint))
+	if err == nil || req.Context().Err() != nil || !isRetriableTransportError(err) {
+		return resp, err
+	}
+
+	newIndex, switched := t.failover(req.Context(), index)
+	if !switched || !replayable {
+		return resp, err
+	}
+
+	// only resend requests the failed controller cannot have applied
+	if !isIdempotent(req.Method) && !isConnectError(err) {
+		return resp, err
+	}
+
+	retryReq := rewriteEndpoint(req, t.endpoints[newIndex])
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		retryReq.Body = body
+	}
+	return t.base.RoundTrip(retryReq)
+}
+
+// selectHealthy probes the endpoints in order and activates the first one that answers.
+// It is called once when the provider is configured, so a standby primary is skipped
+// before the first request. The primary stays active when no endpoint answers.
+func (t *failoverTransport) selectHealthy(ctx context.Context) {
+	for i, endpoint := range t.endpoints {
+		if err := t.probe(ctx, endpoint); err != nil {
+			tflog.Warn(t.logCtx, "Verity API endpoint is not reachable", map[string]interface{}{
+				"endpoint": endpoint.String(),
+				"error":    err.Error(),
+			})
+			continue
+		}
+
+		t.mu.Lock()
+		t.active = i
+		t.mu.Unlock()
+		tflog.Info(t.logCtx, "Using Verity API endpoint", map[string]interface{}{
+			"endpoint": endpoint.String(),
+		})
+		return
+	}
+}
+
+// failover switches away from the endpoint at failedIndex to the next one that answers a
+// probe. Candidates are probed without holding the lock, so requests to a healthy endpoint
+// are not held up; a request that finds another one already failed over uses its endpoint.
+func (t *failoverTransport) failover(ctx context.Context, failedIndex int) (int, bool) {
+	for offset := 1; offset < len(t.endpoints); offset++ {
+		if active, _ := t.activeEndpoint(); active != failedIndex {
+			return active, true
+		}
+
+		candidate := (failedIndex + offset) % len(t.endpoints)
+		if err := t.probe(ctx, t.endpoints[candidate]); err != nil {
+			tflog.Debug(t.logCtx, "Verity API endpoint is not reachable", map[string]interface{}{
+				"endpoint": t.endpoints[candidate].String(),
+				"error":    err.Error(),
+			})
+			continue
+		}
+
+		t.mu.Lock()
+		if t.active != failedIndex {
+			active := t.active
+			t.mu.Unlock()
+			return active, true
+		}
+		t.active = candidate
+		t.mu.Unlock()
+
+		tflog.Warn(t.logCtx, "Verity API endpoint failed, failing over", map[string]interface{}{
+			"failed_endpoint": t.endpoints[failedIndex].String(),
+			"endpoint":        t.endpoints[candidate].String(),
+		})
+		if t.onFailover != nil {
+			t.onFailover()
+		}
+		return candidate, true
+	}
+
+	if active, _ := t.activeEndpoint(); active != failedIndex {
+		return active, true
+	}
+	return failedIndex, false
+}
+
+// probe checks that an endpoint serves the API by requesting its version. Any response
+// below 500 counts, as the version endpoint may require a token the probe does not send.
+func (t *failoverTransport) probe(ctx context.Context, endpoint *url.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String()+"/api/version", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("version endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// rewriteEndpoint returns a copy of req addressed to endpoint.
+func rewriteEndpoint(req *http.Request, endpoint *url.URL) *http.Request {
+	if req.URL.Scheme == endpoint.Scheme && req.URL.Host == endpoint.Host {
+		return req
+	}
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = endpoint.Scheme
+	rewritten.URL.Host = endpoint.Host
+	rewritten.Host = ""
+	return rewritten
+}
+
+// isConnectError reports whether err happened before the request reached the API.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
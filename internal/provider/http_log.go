@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpLogSubsystem is the tflog subsystem of the wire log. Its level can be set separately
+	// with TF_LOG_PROVIDER_VERITY_HTTP.
+	httpLogSubsystem = "http"
+
+	// maxLoggedBodySize caps each logged body, GET responses of whole object types can be large
+	maxLoggedBodySize = 64 * 1024
+
+	redactedValue = "<redacted>"
+)
+
+// httpLogLevel is the amount of detail written by the wire log, set with VERITY_HTTP_LOG.
+type httpLogLevel int
+
+const (
+	httpLogOff httpLogLevel = iota
+	// httpLogBasic logs method, URL, status and duration of every request
+	httpLogBasic
+	// httpLogBody also logs request and response bodies, with credentials redacted
+	httpLogBody
+)
+
+// httpLogLevelFromEnv reads VERITY_HTTP_LOG: "basic", "body" (or "true"/"1"), or unset/"off".
+func httpLogLevelFromEnv() httpLogLevel {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("VERITY_HTTP_LOG"))) {
+	case "basic":
+		return httpLogBasic
+	case "body", "true", "1":
+		return httpLogBody
+	default:
+		return httpLogOff
+	}
+}
+
+// httpLogTransport writes every request sent to the API to the http tflog subsystem.
+// Requests sent by the bulk operation manager do not carry a logger in their context, so
+// the transport logs through the context the provider was configured with.
+type httpLogTransport struct {
+	base   http.RoundTripper
+	logCtx context.Context
+	level  httpLogLevel
+}
+
+func newHTTPLogTransport(ctx context.Context, base http.RoundTripper, level httpLogLevel) http.RoundTripper {
+	if level == httpLogOff {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &httpLogTransport{
+		base:   base,
+		logCtx: tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_VERITY_HTTP")),
+		level:  level,
+	}
+}
+
+func (t *httpLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+	if t.level == httpLogBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			fields["request_body"] = redactBody(data)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["duration"] = time.Since(start).String()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(t.logCtx, httpLogSubsystem, "API request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	if t.level == httpLogBody && resp.Body != nil {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		fields["response_body"] = redactBody(data)
+	}
+
+	tflog.SubsystemDebug(t.logCtx, httpLogSubsystem, "API request", fields)
+	return resp, nil
+}
+
+// redactBody returns a JSON body for the log with credential values replaced. Bodies that
+// are not JSON are not logged, as there is no reliable way to redact them.
+func redactBody(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactValue(decoded)); err != nil {
+		return "<body omitted>"
+	}
+	redacted := bytes.TrimSpace(buf.Bytes())
+	if len(redacted) > maxLoggedBodySize {
+		return string(redacted[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if isSensitiveKey(key) {
+				if nested != nil && nested != "" {
+					value[key] = redactedValue
+				}
+				continue
+			}
+			value[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = redactValue(nested)
+		}
+	}
+	return v
+}
+
+// isSensitiveKey reports whether a JSON field holds a credential: passwords, passphrases,
+// tokens, SNMP community strings and the encrypted copies the API returns as *_encrypted.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "password") ||
+		strings.Contains(key, "passphrase") ||
+		strings.Contains(key, "token") ||
+		strings.HasSuffix(key, "community_string") ||
+		strings.HasSuffix(key, "_encrypted")
+}
@@ -9,8 +9,12 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"terraform-provider-verity/internal/auth"
@@ -19,6 +23,7 @@ import (
 	"terraform-provider-verity/openapi"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -32,15 +37,25 @@ type verityProvider struct {
 	version string
 }
 
+// modeAuto detects the mode from the datacenter field of the version endpoint
+const modeAuto = "auto"
+
 type providerContext struct {
 	client       *openapi.APIClient
 	tokenManager *auth.TokenManager
+	authMutex    sync.Mutex
 	config       *openapi.Configuration
 	credentials  struct {
 		username string
 		password string
+		apiToken string
+		exec     *execCredential
+		// apiTokenRejected is set once the API answered apiToken with a 401, after
+		// which the remaining credentials are used
+		apiTokenRejected atomic.Bool
 	}
 	mode           string
+	changeset      string
 	apiVersion     string
 	workDir        string
 	responseCache  map[string]interface{}
@@ -50,13 +65,53 @@ type providerContext struct {
 	debounceTimer  *time.Timer
 	debounceActive bool
 	debounceMutex  sync.Mutex
+
+	// defaultObjectProperties are merged into the object_properties block of every resource
+	defaultObjectProperties map[string]string
+}
+
+type retryModel struct {
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	InitialDelay types.String `tfsdk:"initial_delay"`
+	MaxDelay     types.String `tfsdk:"max_delay"`
+}
+
+type bulkModel struct {
+	BatchCollectionWindow  types.String `tfsdk:"batch_collection_window"`
+	MaxBatchDelay          types.String `tfsdk:"max_batch_delay"`
+	ResponseProcessorDelay types.String `tfsdk:"response_processor_delay"`
+	DebounceDelay          types.String `tfsdk:"debounce_delay"`
+	AdaptiveDebounce       types.Bool   `tfsdk:"adaptive_debounce"`
+	MaxBatchSize           types.Int64  `tfsdk:"max_batch_size"`
+	MaxDeleteBatchSize     types.Int64  `tfsdk:"max_delete_batch_size"`
+	MaxParallelBatches     types.Int64  `tfsdk:"max_parallel_batches"`
+	OnError                types.String `tfsdk:"on_error"`
+	RollbackOnFailure      types.Bool   `tfsdk:"rollback_on_failure"`
 }
 
 type verityProviderModel struct {
-	URI      types.String `tfsdk:"uri"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Mode     types.String `tfsdk:"mode"`
+	URI                types.String         `tfsdk:"uri"`
+	FailoverURIs       types.List           `tfsdk:"failover_uris"`
+	Username           types.String         `tfsdk:"username"`
+	Password           types.String         `tfsdk:"password"`
+	APIToken           types.String         `tfsdk:"api_token"`
+	Exec               *execCredentialModel `tfsdk:"exec"`
+	Mode               types.String         `tfsdk:"mode"`
+	Changeset          types.String         `tfsdk:"changeset"`
+	DryRun             types.Bool           `tfsdk:"dry_run"`
+	DryRunFile         types.String         `tfsdk:"dry_run_file"`
+	CACertFile         types.String         `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String         `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String         `tfsdk:"client_cert"`
+	ClientKey          types.String         `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool           `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.String         `tfsdk:"request_timeout"`
+	OperationTimeout   types.String         `tfsdk:"operation_timeout"`
+	ProxyURL           types.String         `tfsdk:"proxy_url"`
+	MaxIdleConns       types.Int64          `tfsdk:"max_idle_conns"`
+	Retry              *retryModel          `tfsdk:"retry"`
+	DefaultObjectProps types.Map            `tfsdk:"default_object_properties"`
+	Bulk               *bulkModel           `tfsdk:"bulk"`
 }
 
 func New(version string) func() provider.Provider {
@@ -80,6 +135,12 @@ func (p *verityProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"failover_uris": schema.ListAttribute{
+				Description: "Base URLs of standby controllers. Requests fail over to the first of uri and these that answers when the active controller cannot be reached.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
 			"username": schema.StringAttribute{
 				Description: "API username",
 				Optional:    true,
@@ -90,10 +151,153 @@ func (p *verityProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"api_token": schema.StringAttribute{
+				Description: "Pre-issued API token. Used instead of username and password.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"exec": schema.SingleNestedAttribute{
+				Description: "External command that prints an API token as JSON, e.g. {\"token\": \"...\", \"expires_in\": 3600}. Used instead of username and password.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"command": schema.StringAttribute{
+						Description: "Command to run",
+						Required:    true,
+					},
+					"args": schema.ListAttribute{
+						Description: "Arguments passed to the command",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"env": schema.MapAttribute{
+						Description: "Environment variables set for the command, in addition to the provider's environment",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+			},
 			"mode": schema.StringAttribute{
-				Description: "Mode to operate in: 'datacenter' or 'campus'",
+				Description: "Mode to operate in: 'datacenter', 'campus' or 'auto'. When unset or 'auto', the mode is detected from the version endpoint.",
+				Optional:    true,
+			},
+			"changeset": schema.StringAttribute{
+				Description: "Name of the changeset all reads and writes are scoped to. When unset, changes are applied directly to the live configuration.",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Record the bulk requests the provider would send, in the order it would send them, instead of sending them. Every resource change then fails, so Terraform stops without changing its state. As Terraform skips the resources whose dependencies failed, only the resources that do not depend on other changed resources are recorded. Can also be set with the VERITY_DRY_RUN environment variable. Defaults to false.",
+				Optional:    true,
+			},
+			"dry_run_file": schema.StringAttribute{
+				Description: "File the requests of a dry run are written to, one JSON object per line. Can also be set with the VERITY_DRY_RUN_FILE environment variable. When unset, the requests are written to the provider log.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded CA certificate bundle used to verify the API server certificate, in addition to the system trust store",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates used to verify the API server certificate, in addition to the system trust store",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate, or a path to one, for mutual TLS. Requires client_key.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of client_cert, or a path to one",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the API server certificate. Only use this against lab controllers.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout of a single HTTP request to the API, including its retries, as a duration such as \"60s\". Defaults to no timeout.",
+				Optional:    true,
+			},
+			"operation_timeout": schema.StringAttribute{
+				Description: "Timeout of a bulk API operation, including waiting for its batch to be sent, as a duration such as \"10m\". Defaults to 5m.",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP(S) or SOCKS5 proxy for API requests. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+				Optional:    true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open to the API. Defaults to 2.",
 				Optional:    true,
 			},
+			"default_object_properties": schema.MapAttribute{
+				Description: "Values for object_properties fields such as group and notes, applied to every resource whose object_properties block does not set them.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"retry": schema.SingleNestedAttribute{
+				Description: "Retry policy for API requests that fail because the API is busy or temporarily unavailable",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_retries": schema.Int64Attribute{
+						Description: "Number of times a failed request is retried, at most 20. 0 disables retries. Defaults to 5.",
+						Optional:    true,
+					},
+					"initial_delay": schema.StringAttribute{
+						Description: "Delay before the first retry, doubled for every further retry, as a duration such as \"500ms\". Defaults to 500ms.",
+						Optional:    true,
+					},
+					"max_delay": schema.StringAttribute{
+						Description: "Maximum delay between retries, as a duration such as \"30s\". Defaults to 30s.",
+						Optional:    true,
+					},
+				},
+			},
+			"bulk": schema.SingleNestedAttribute{
+				Description: "Batching of resource operations into bulk API requests",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"batch_collection_window": schema.StringAttribute{
+						Description: "How long to wait after the last queued operation before sending a batch, as a duration such as \"2s\". Defaults to 2s.",
+						Optional:    true,
+					},
+					"max_batch_delay": schema.StringAttribute{
+						Description: "How long an operation stage waits for operations of resources applied concurrently, as a duration such as \"5s\". Defaults to 5s.",
+						Optional:    true,
+					},
+					"response_processor_delay": schema.StringAttribute{
+						Description: "How long to wait for server assigned values before reading them back after a request, as a duration such as \"5s\". Defaults to 5s.",
+						Optional:    true,
+					},
+					"debounce_delay": schema.StringAttribute{
+						Description: "How long to wait for further operations before the queued ones are sent, as a duration such as \"15s\". Defaults to 15s.",
+						Optional:    true,
+					},
+					"adaptive_debounce": schema.BoolAttribute{
+						Description: "Send the queued operations as soon as Terraform stops queueing new ones, instead of always waiting debounce_delay. Defaults to false.",
+						Optional:    true,
+					},
+					"max_batch_size": schema.Int64Attribute{
+						Description: "Maximum number of resources in a PUT or PATCH request. Defaults to 1000.",
+						Optional:    true,
+					},
+					"max_delete_batch_size": schema.Int64Attribute{
+						Description: "Maximum number of resources in a DELETE request. Defaults to 100.",
+						Optional:    true,
+					},
+					"max_parallel_batches": schema.Int64Attribute{
+						Description: "Maximum number of bulk requests for resource types that do not depend on each other to send concurrently. Defaults to 1 (one resource type at a time).",
+						Optional:    true,
+					},
+					"rollback_on_failure": schema.BoolAttribute{
+						Description: "Undo the changes already applied when a bulk request fails: created objects are deleted, and updated or deleted objects are restored to their previous values. Only the requests sent together in one wave are undone: Terraform sends a resource after the resources it references have completed, so a failure never undoes the resources it depends on, such as the tenant of a failed service. Cannot be combined with on_error = \"continue\". Defaults to false.",
+						Optional:    true,
+					},
+					"on_error": schema.StringAttribute{
+						Description: "What happens to the remaining operations when a bulk request fails: \"abort\" cancels all of them, \"continue\" only cancels the operations that depend on the failed resource type. Defaults to abort.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -124,29 +328,188 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		tflog.Debug(ctx, "Password not provided in configuration, using environment variable")
 	}
 
+	apiToken := config.APIToken.ValueString()
+	if apiToken == "" {
+		apiToken = os.Getenv("TF_VAR_api_token")
+		tflog.Debug(ctx, "API token not provided in configuration, checking environment variable")
+	}
+
+	execCred, err := newExecCredential(ctx, config.Exec)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Exec Credential",
+			err.Error(),
+		)
+		return
+	}
+
 	mode := config.Mode.ValueString()
 	if mode == "" {
 		mode = os.Getenv("TF_VAR_mode")
 		tflog.Debug(ctx, "Mode not provided in configuration, checking environment variable")
 	}
 
-	if mode == "" {
+	changeset := config.Changeset.ValueString()
+	if changeset == "" {
+		changeset = os.Getenv("TF_VAR_changeset")
+		tflog.Debug(ctx, "Changeset not provided in configuration, checking environment variable")
+	}
+
+	dryRun := config.DryRun.ValueBool()
+	if config.DryRun.IsNull() {
+		if v := os.Getenv("VERITY_DRY_RUN"); v != "" {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid Dry Run",
+					fmt.Sprintf("VERITY_DRY_RUN must be a boolean, got: %s", v),
+				)
+				return
+			}
+			dryRun = parsed
+		}
+	}
+	dryRunFile := config.DryRunFile.ValueString()
+	if dryRunFile == "" {
+		dryRunFile = os.Getenv("VERITY_DRY_RUN_FILE")
+	}
+
+	tlsOpts := tlsSettings{
+		caCertFile: config.CACertFile.ValueString(),
+		caCertPEM:  config.CACertPEM.ValueString(),
+		clientCert: config.ClientCert.ValueString(),
+		clientKey:  config.ClientKey.ValueString(),
+	}
+	if tlsOpts.caCertFile == "" {
+		tlsOpts.caCertFile = os.Getenv("TF_VAR_ca_cert_file")
+	}
+	if tlsOpts.caCertPEM == "" {
+		tlsOpts.caCertPEM = os.Getenv("TF_VAR_ca_cert_pem")
+	}
+	if tlsOpts.clientCert == "" {
+		tlsOpts.clientCert = os.Getenv("TF_VAR_client_cert")
+	}
+	if tlsOpts.clientKey == "" {
+		tlsOpts.clientKey = os.Getenv("TF_VAR_client_key")
+	}
+	if !config.InsecureSkipVerify.IsNull() {
+		tlsOpts.insecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	} else if v := os.Getenv("TF_VAR_insecure_skip_verify"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Insecure Skip Verify",
+				fmt.Sprintf("TF_VAR_insecure_skip_verify must be a boolean, got: %s", v),
+			)
+			return
+		}
+		tlsOpts.insecureSkipVerify = parsed
+		tflog.Debug(ctx, "Insecure skip verify not provided in configuration, using environment variable")
+	}
+
+	requestTimeout, err := durationSetting(config.RequestTimeout, "request_timeout", "TF_VAR_request_timeout")
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Missing Mode Configuration",
-			"The 'mode' parameter is required and must be set to either 'datacenter' or 'campus'. "+
-				"Please specify the mode in your provider configuration block or set the TF_VAR_mode environment variable.",
+			"Invalid Request Timeout",
+			err.Error(),
 		)
 		return
 	}
 
-	if mode != "datacenter" && mode != "campus" {
+	operationTimeout, err := durationSetting(config.OperationTimeout, "operation_timeout", "TF_VAR_operation_timeout")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Operation Timeout",
+			err.Error(),
+		)
+		return
+	}
+
+	retryConfig, err := retrySettings(config.Retry)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Retry Configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	var defaultObjectProperties map[string]string
+	if !config.DefaultObjectProps.IsNull() && !config.DefaultObjectProps.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultObjectProps.ElementsAs(ctx, &defaultObjectProperties, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if unknown := p.unknownObjectPropertiesFields(ctx, defaultObjectProperties); len(unknown) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_object_properties"),
+				"Invalid Default Object Properties",
+				fmt.Sprintf("No resource has an object_properties field named %s.", strings.Join(unknown, ", ")),
+			)
+			return
+		}
+	}
+
+	bulkConfig, err := bulkSettings(config.Bulk)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Bulk Configuration",
+			err.Error(),
+		)
+		return
+	}
+
+	proxyURL := config.ProxyURL.ValueString()
+	if proxyURL == "" {
+		proxyURL = os.Getenv("TF_VAR_proxy_url")
+	}
+	var parsedProxyURL *url.URL
+	if proxyURL != "" {
+		parsedProxyURL, err = parseProxyURL(proxyURL)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Proxy URL",
+				fmt.Sprintf("Invalid proxy_url: %v", err),
+			)
+			return
+		}
+	}
+
+	var maxIdleConns int64
+	if !config.MaxIdleConns.IsNull() {
+		maxIdleConns = config.MaxIdleConns.ValueInt64()
+	} else if v := os.Getenv("TF_VAR_max_idle_conns"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Max Idle Connections",
+				fmt.Sprintf("TF_VAR_max_idle_conns must be an integer, got: %s", v),
+			)
+			return
+		}
+		maxIdleConns = parsed
+		tflog.Debug(ctx, "Max idle connections not provided in configuration, using environment variable")
+	}
+	if maxIdleConns < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Max Idle Connections",
+			fmt.Sprintf("max_idle_conns must not be negative, got: %d", maxIdleConns),
+		)
+		return
+	}
+
+	if mode != "" && mode != modeAuto && mode != "datacenter" && mode != "campus" {
 		resp.Diagnostics.AddError(
 			"Invalid Mode",
-			"The mode must be either 'datacenter' or 'campus'. "+
+			"The mode must be 'datacenter', 'campus' or 'auto'. "+
 				"Got: "+mode,
 		)
 		return
 	}
+	// an empty mode is detected from the version endpoint
+	if mode == modeAuto {
+		mode = ""
+	}
 
 	if uri == "" {
 		resp.Diagnostics.AddError(
@@ -158,43 +521,62 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	if username == "" {
+	failoverURIs, err := failoverURISetting(ctx, config.FailoverURIs)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Missing API Username",
-			"The provider cannot create the Verity API client as the username is missing. "+
-				"Set the username attribute in the provider configuration or "+
-				"set the TF_VAR_username environment variable.",
+			"Invalid Failover URIs",
+			err.Error(),
 		)
 		return
 	}
-
-	if password == "" {
-		resp.Diagnostics.AddError(
-			"Missing API Password",
-			"The provider cannot create the Verity API client as the password is missing. "+
-				"Set the password attribute in the provider configuration or "+
-				"set the TF_VAR_password environment variable.",
-		)
-		return
+	failoverEndpoints := make([]*url.URL, 0, len(failoverURIs))
+	for _, failoverURI := range failoverURIs {
+		endpoint, err := parseEndpoint(failoverURI)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Failover URIs",
+				fmt.Sprintf("Invalid failover_uris entry: %v", err),
+			)
+			return
+		}
+		failoverEndpoints = append(failoverEndpoints, endpoint)
 	}
 
-	apiConfig := openapi.NewConfiguration()
-
-	jar, err := cookiejar.New(nil)
-	if err != nil {
+	if apiToken != "" && execCred != nil {
 		resp.Diagnostics.AddError(
-			"Failed to create cookie jar",
-			fmt.Sprintf("Failed to create cookie jar: %v", err),
+			"Conflicting Credentials",
+			"Only one of api_token and exec can be set.",
 		)
 		return
 	}
 
-	tokenManager := auth.NewTokenManager(jar)
+	// username and password are only required when no token source is configured
+	if apiToken == "" && execCred == nil {
+		if username == "" {
+			resp.Diagnostics.AddError(
+				"Missing API Username",
+				"The provider cannot create the Verity API client as the username is missing. "+
+					"Set the username attribute in the provider configuration or "+
+					"set the TF_VAR_username environment variable, "+
+					"or authenticate with api_token or exec instead.",
+			)
+			return
+		}
 
-	apiConfig.HTTPClient = &http.Client{
-		Jar: jar,
+		if password == "" {
+			resp.Diagnostics.AddError(
+				"Missing API Password",
+				"The provider cannot create the Verity API client as the password is missing. "+
+					"Set the password attribute in the provider configuration or "+
+					"set the TF_VAR_password environment variable, "+
+					"or authenticate with api_token or exec instead.",
+			)
+			return
+		}
 	}
 
+	apiConfig := openapi.NewConfiguration()
+
 	baseURL := uri
 	tflog.Debug(ctx, "Configuring provider", map[string]interface{}{
 		"base_url": baseURL,
@@ -237,7 +619,52 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		"url": serverURL,
 	})
 
-	apiConfig.Debug = true
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create cookie jar",
+			fmt.Sprintf("Failed to create cookie jar: %v", err),
+		)
+		return
+	}
+
+	tokenManager := auth.NewTokenManager(jar)
+
+	tlsConfig, err := buildTLSConfig(tlsOpts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid TLS Configuration",
+			err.Error(),
+		)
+		return
+	}
+	if tlsOpts.insecureSkipVerify {
+		tflog.Warn(ctx, "TLS certificate verification of the API server is disabled")
+	}
+	transport := newHTTPTransport(httpTransportSettings{
+		tlsConfig:    tlsConfig,
+		proxyURL:     parsedProxyURL,
+		maxIdleConns: int(maxIdleConns),
+	})
+	transport = newHTTPLogTransport(ctx, transport, httpLogLevelFromEnv())
+	if len(failoverEndpoints) > 0 {
+		endpoints := append([]*url.URL{{Scheme: parsedURL.Scheme, Host: parsedURL.Host}}, failoverEndpoints...)
+		failover := newFailoverTransport(ctx, transport, endpoints, tokenManager.Clear)
+		failover.selectHealthy(ctx)
+		transport = failover
+	}
+	transport = newRetryTransport(ctx, transport, retryConfig)
+
+	apiConfig.HTTPClient = &http.Client{
+		Jar:       jar,
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	if changeset != "" {
+		apiConfig.HTTPClient.Transport = newChangesetTransport(transport, changeset)
+		tflog.Info(ctx, "Scoping all API requests to changeset: "+changeset)
+	}
+
 	client := openapi.NewAPIClient(apiConfig)
 
 	provCtx := &providerContext{
@@ -246,21 +673,21 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		tokenManager:   tokenManager,
 		responseCache:  make(map[string]interface{}),
 		mode:           mode,
+		changeset:      changeset,
 		workDir:        utils.GetWorkDirForProvider(baseURL),
 		debounceActive: true,
+
+		defaultObjectProperties: defaultObjectProperties,
 	}
 
 	provCtx.credentials.username = username
 	provCtx.credentials.password = password
+	provCtx.credentials.apiToken = apiToken
+	provCtx.credentials.exec = execCred
 
-	tflog.Info(ctx, "Configuring provider with mode: "+mode)
-
-	bulkManager := bulkops.GetManager(client, clearCache, provCtx, mode)
-	tflog.Info(ctx, "Initialized bulk operation manager with manual batching mode")
-
-	provCtx.bulkOpsMgr = bulkManager
-
-	provCtx.initBulkOpsTicker(ctx)
+	apiConfig.HTTPClient.Transport = newReauthTransport(apiConfig.HTTPClient.Transport, func(ctx context.Context, rejectedToken string, statusCode int) (string, error) {
+		return reauthenticate(ctx, provCtx, rejectedToken, statusCode)
+	})
 
 	if err := authenticate(ctx, provCtx); err != nil {
 		resp.Diagnostics.AddError(
@@ -270,8 +697,6 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	tflog.SetField(ctx, "verity_mode", provCtx.mode)
-
 	apiVersion, err := getApiVersion(ctx, provCtx)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -289,8 +714,69 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	if utils.IsNewerAPIVersion(apiVersion) {
+		resp.Diagnostics.AddWarning(
+			"Newer API Version",
+			fmt.Sprintf("The server is running API version %s, which is newer than API version %s this provider was built for. "+
+				"Attributes added after %s cannot be managed until the provider is upgraded.",
+				apiVersion, utils.GetSupportedAPIVersionString(), utils.GetSupportedAPIVersionString()),
+		)
+	}
+
 	provCtx.apiVersion = apiVersion
 
+	tflog.SetField(ctx, "verity_mode", provCtx.mode)
+	tflog.Info(ctx, "Configuring provider with mode: "+provCtx.mode)
+
+	bulkManager := bulkops.GetManager(client, clearCache, provCtx, provCtx.mode)
+	bulkManager.SetOperationTimeout(operationTimeout)
+	bulkManager.SetSettings(bulkConfig)
+	bulkManager.SetDryRun(dryRun, dryRunFile)
+	if dryRun {
+		destination := "the provider log"
+		if dryRunFile != "" {
+			destination = dryRunFile
+		}
+		resp.Diagnostics.AddWarning(
+			"Dry Run",
+			fmt.Sprintf("Bulk requests are written to %s instead of being sent to the API. Every resource change fails without changing the Terraform state. "+
+				"Terraform skips the resources that depend on a failed resource, so the requests of resources referencing other resources changed in the same apply are not recorded.", destination),
+		)
+	}
+	if provCtx.workDir != "" {
+		bulkManager.SetOperationJournal(filepath.Join(provCtx.workDir, bulkops.OperationJournalFile(baseURL, changeset)))
+	}
+	interrupted, err := bulkManager.RecoverOperationJournal(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Operation Journal Not Checked",
+			fmt.Sprintf("The bulk requests of the previous run could not be checked: %s", err),
+		)
+	}
+	if len(interrupted) > 0 {
+		lines := make([]string, 0, len(interrupted))
+		for _, batch := range interrupted {
+			lines = append(lines, "- "+batch.String())
+		}
+		resp.Diagnostics.AddWarning(
+			"Possibly Half-Applied Bulk Operations",
+			fmt.Sprintf("A previous run stopped while these bulk requests were in flight, so Terraform may not have recorded their changes:\n%s\n"+
+				"Compare the objects with the Terraform state, then import or remove them as needed.", strings.Join(lines, "\n")),
+		)
+	}
+	tflog.Info(ctx, "Initialized bulk operation manager with manual batching mode", map[string]interface{}{
+		"max_parallel_batches":  bulkConfig.MaxParallelBatches,
+		"operation_timeout":     bulkManager.OperationTimeout().String(),
+		"debounce_delay":        bulkConfig.DebounceDelay.String(),
+		"adaptive_debounce":     bulkConfig.AdaptiveDebounce,
+		"max_batch_size":        bulkConfig.MaxBatchSize,
+		"max_delete_batch_size": bulkConfig.MaxDeleteBatchSize,
+	})
+
+	provCtx.bulkOpsMgr = bulkManager
+
+	provCtx.initBulkOpsTicker(ctx)
+
 	ctxWithProviderData := context.WithValue(ctx, "providerData", provCtx)
 
 	resp.DataSourceData = provCtx
@@ -298,10 +784,205 @@ func (p *verityProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	tflog.Info(ctxWithProviderData, "Provider configured", map[string]interface{}{
 		"mode":        provCtx.mode,
+		"changeset":   provCtx.changeset,
 		"api_version": provCtx.apiVersion,
 	})
 }
 
+// durationSetting parses a duration provider attribute, falling back to an environment
+// variable. An unset value is returned as 0.
+func durationSetting(value types.String, attrName, envVar string) (time.Duration, error) {
+	raw := value.ValueString()
+	source := attrName
+	if raw == "" {
+		source = envVar
+		raw = os.Getenv(envVar)
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as \"30s\" or \"5m\", got: %s", source, raw)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got: %s", source, raw)
+	}
+	return d, nil
+}
+
+// failoverURISetting returns the failover_uris attribute, falling back to the comma separated
+// TF_VAR_failover_uris environment variable.
+func failoverURISetting(ctx context.Context, value types.List) ([]string, error) {
+	if value.IsNull() || value.IsUnknown() {
+		var uris []string
+		for _, uri := range strings.Split(os.Getenv("TF_VAR_failover_uris"), ",") {
+			if uri = strings.TrimSpace(uri); uri != "" {
+				uris = append(uris, uri)
+			}
+		}
+		return uris, nil
+	}
+
+	var uris []string
+	if diags := value.ElementsAs(ctx, &uris, false); diags.HasError() {
+		return nil, fmt.Errorf("failover_uris must be a list of strings")
+	}
+	return uris, nil
+}
+
+// maxRetriesLimit caps retry.max_retries: with the default delays, further retries would only
+// keep a failing apply waiting for hours.
+const maxRetriesLimit = 20
+
+// retrySettings returns the retry policy of the API client: the defaults, overridden by the
+// retry attribute and the TF_VAR_retry_* environment variables.
+func retrySettings(model *retryModel) (utils.RetryConfig, error) {
+	config := utils.DefaultRetryConfig()
+
+	var settings retryModel
+	if model != nil {
+		settings = *model
+	}
+
+	if !settings.MaxRetries.IsNull() {
+		config.MaxRetries = int(settings.MaxRetries.ValueInt64())
+	} else if v := os.Getenv("TF_VAR_retry_max_retries"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return config, fmt.Errorf("TF_VAR_retry_max_retries must be an integer, got: %s", v)
+		}
+		config.MaxRetries = parsed
+	}
+	if config.MaxRetries < 0 || config.MaxRetries > maxRetriesLimit {
+		return config, fmt.Errorf("retry.max_retries must be between 0 and %d, got: %d", maxRetriesLimit, config.MaxRetries)
+	}
+
+	initialDelay, err := durationSetting(settings.InitialDelay, "retry.initial_delay", "TF_VAR_retry_initial_delay")
+	if err != nil {
+		return config, err
+	}
+	if initialDelay > 0 {
+		config.InitialDelay = initialDelay
+	}
+
+	maxDelay, err := durationSetting(settings.MaxDelay, "retry.max_delay", "TF_VAR_retry_max_delay")
+	if err != nil {
+		return config, err
+	}
+	if maxDelay > 0 {
+		config.MaxDelay = maxDelay
+	}
+
+	if config.InitialDelay > config.MaxDelay {
+		return config, fmt.Errorf("retry.initial_delay (%s) must not exceed retry.max_delay (%s)", config.InitialDelay, config.MaxDelay)
+	}
+	return config, nil
+}
+
+// bulkSettings returns the batching settings of the bulk operation manager: the defaults,
+// overridden by the bulk attribute and the TF_VAR_bulk_* environment variables.
+func bulkSettings(model *bulkModel) (bulkops.Settings, error) {
+	settings := bulkops.DefaultSettings()
+
+	var cfg bulkModel
+	if model != nil {
+		cfg = *model
+	}
+
+	durations := []struct {
+		value   types.String
+		name    string
+		setting *time.Duration
+	}{
+		{cfg.BatchCollectionWindow, "batch_collection_window", &settings.BatchCollectionWindow},
+		{cfg.MaxBatchDelay, "max_batch_delay", &settings.MaxBatchDelay},
+		{cfg.ResponseProcessorDelay, "response_processor_delay", &settings.ResponseProcessorDelay},
+		{cfg.DebounceDelay, "debounce_delay", &settings.DebounceDelay},
+	}
+	for _, d := range durations {
+		envVar := "TF_VAR_bulk_" + d.name
+		if d.value.ValueString() == "" && os.Getenv(envVar) == "" {
+			continue
+		}
+		value, err := durationSetting(d.value, "bulk."+d.name, envVar)
+		if err != nil {
+			return settings, err
+		}
+		// only the response processor delay can be skipped entirely
+		if value == 0 && d.name != "response_processor_delay" {
+			return settings, fmt.Errorf("bulk.%s must be greater than 0", d.name)
+		}
+		*d.setting = value
+	}
+
+	if !cfg.AdaptiveDebounce.IsNull() {
+		settings.AdaptiveDebounce = cfg.AdaptiveDebounce.ValueBool()
+	} else if v := os.Getenv("TF_VAR_bulk_adaptive_debounce"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return settings, fmt.Errorf("TF_VAR_bulk_adaptive_debounce must be a boolean, got: %s", v)
+		}
+		settings.AdaptiveDebounce = parsed
+	}
+
+	sizes := []struct {
+		value   types.Int64
+		name    string
+		setting *int
+	}{
+		{cfg.MaxBatchSize, "max_batch_size", &settings.MaxBatchSize},
+		{cfg.MaxDeleteBatchSize, "max_delete_batch_size", &settings.MaxDeleteBatchSize},
+		{cfg.MaxParallelBatches, "max_parallel_batches", &settings.MaxParallelBatches},
+	}
+	for _, size := range sizes {
+		value := size.value.ValueInt64()
+		if size.value.IsNull() {
+			v := os.Getenv("TF_VAR_bulk_" + size.name)
+			if v == "" {
+				continue
+			}
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return settings, fmt.Errorf("TF_VAR_bulk_%s must be an integer, got: %s", size.name, v)
+			}
+			value = parsed
+		}
+		if value < 1 {
+			return settings, fmt.Errorf("bulk.%s must be at least 1, got: %d", size.name, value)
+		}
+		*size.setting = int(value)
+	}
+
+	onError := cfg.OnError.ValueString()
+	if onError == "" {
+		onError = os.Getenv("TF_VAR_bulk_on_error")
+	}
+	switch onError {
+	case "", "abort":
+	case "continue":
+		settings.ContinueOnError = true
+	default:
+		return settings, fmt.Errorf("bulk.on_error must be \"abort\" or \"continue\", got: %q", onError)
+	}
+
+	if !cfg.RollbackOnFailure.IsNull() {
+		settings.RollbackOnFailure = cfg.RollbackOnFailure.ValueBool()
+	} else if v := os.Getenv("TF_VAR_bulk_rollback_on_failure"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return settings, fmt.Errorf("TF_VAR_bulk_rollback_on_failure must be a boolean, got: %s", v)
+		}
+		settings.RollbackOnFailure = parsed
+	}
+	if settings.RollbackOnFailure && settings.ContinueOnError {
+		return settings, fmt.Errorf("bulk.rollback_on_failure cannot be combined with on_error = \"continue\"")
+	}
+
+	return settings, nil
+}
+
 func getApiVersion(ctx context.Context, provCtx *providerContext) (string, error) {
 	if err := authenticate(ctx, provCtx); err != nil {
 		return "", fmt.Errorf("authentication failed when getting API version: %w", err)
@@ -337,25 +1018,26 @@ func getApiVersion(ctx context.Context, provCtx *providerContext) (string, error
 	}
 
 	if versionPayload.Datacenter != nil {
-		systemIsDatacenter := *versionPayload.Datacenter
-		configuredIsDatacenter := provCtx.mode == "datacenter"
-
-		if systemIsDatacenter != configuredIsDatacenter {
-			var systemMode, configuredMode string
-			if systemIsDatacenter {
-				systemMode = "datacenter"
-			} else {
-				systemMode = "campus"
-			}
-			configuredMode = provCtx.mode
-
-			return "", fmt.Errorf("Mode mismatch: provider is configured for '%s' mode but the system is running in '%s' mode. Please update the provider configuration to match the actual system type", configuredMode, systemMode)
+		systemMode := "campus"
+		if *versionPayload.Datacenter {
+			systemMode = "datacenter"
 		}
 
-		tflog.Info(ctx, "Mode validation successful", map[string]interface{}{
-			"configured_mode": provCtx.mode,
-			"system_mode":     systemIsDatacenter,
-		})
+		if provCtx.mode == "" {
+			provCtx.mode = systemMode
+			tflog.Info(ctx, "Detected mode from the version endpoint", map[string]interface{}{
+				"mode": systemMode,
+			})
+		} else if systemMode != provCtx.mode {
+			return "", fmt.Errorf("Mode mismatch: provider is configured for '%s' mode but the system is running in '%s' mode. Please update the provider configuration to match the actual system type", provCtx.mode, systemMode)
+		} else {
+			tflog.Info(ctx, "Mode validation successful", map[string]interface{}{
+				"configured_mode": provCtx.mode,
+				"system_mode":     systemMode,
+			})
+		}
+	} else if provCtx.mode == "" {
+		return "", fmt.Errorf("the version endpoint does not report whether the system is a datacenter or campus controller, so the mode cannot be detected. Set mode to 'datacenter' or 'campus' in the provider configuration or the TF_VAR_mode environment variable")
 	} else {
 		tflog.Debug(ctx, "No datacenter field in version response, skipping mode validation")
 	}
@@ -400,6 +1082,8 @@ func getAllResources() []func() resource.Resource {
 		NewVerityServicePortProfileResource,
 		NewVerityVoicePortProfileResource,
 		NewVeritySwitchpointResource,
+		NewVeritySwitchpointUpgradeResource,
+		NewVeritySwitchpointMaintenanceResource,
 		NewVerityDeviceControllerResource,
 		NewVerityAsPathAccessListResource,
 		NewVerityCommunityListResource,
@@ -413,6 +1097,7 @@ func getAllResources() []func() resource.Resource {
 		NewVerityRouteMapResource,
 		NewVeritySfpBreakoutResource,
 		NewVeritySiteResource,
+		NewVerityImageUpdateSetResource,
 		NewVerityPodResource,
 		NewVerityPortAclResource,
 		NewVeritySflowCollectorResource,
@@ -427,24 +1112,62 @@ func getAllResources() []func() resource.Resource {
 	}
 }
 
+// unknownObjectPropertiesFields returns the keys of defaults, sorted and quoted, that are not a
+// string field of the object_properties block of any resource.
+func (p *verityProvider) unknownObjectPropertiesFields(ctx context.Context, defaults map[string]string) []string {
+	known := make(map[string]bool)
+	for _, newResource := range p.Resources(ctx) {
+		var schemaResp resource.SchemaResponse
+		newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		for field := range utils.ObjectPropertiesStringFields(ctx, schemaResp.Schema) {
+			known[field] = true
+		}
+	}
+
+	var unknown []string
+	for field := range defaults {
+		if !known[field] {
+			unknown = append(unknown, strconv.Quote(field))
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
 func (p *verityProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{
+	dataSources := []func() datasource.DataSource{
 		NewVerityStateImporterDataSource,
+		NewVeritySwitchpointCurrentConfigDataSource,
 	}
+	return append(dataSources, objectDataSources()...)
 }
 
 func (p *providerContext) initBulkOpsTicker(ctx context.Context) {
 	p.tickChannel = make(chan struct{}, 100)
 
 	go func() {
+		var lastTick time.Time
+		var longestGap, delay time.Duration
 		for range p.tickChannel {
+			settings := p.bulkOpsMgr.Settings()
+
+			// a gap longer than the previous delay means the queue was flushed and a new burst began
+			now := time.Now()
+			if gap := now.Sub(lastTick); !lastTick.IsZero() && gap < delay && gap > longestGap {
+				longestGap = gap
+			} else if gap >= delay {
+				longestGap = 0
+			}
+			lastTick = now
+			delay = settings.AdaptiveDebounceDelay(longestGap)
+
 			p.debounceMutex.Lock()
 			if p.debounceTimer != nil {
 				p.debounceTimer.Stop()
 			}
 
 			// when no new ticks arrive, execute operations
-			p.debounceTimer = time.AfterFunc(bulkops.DebounceDelay, func() {
+			p.debounceTimer = time.AfterFunc(delay, func() {
 				tflog.Debug(ctx, "Bulk operation debounce timer expired, executing pending operations")
 				if diags := p.bulkOpsMgr.ExecuteAllPendingOperations(ctx); diags != nil {
 					tflog.Error(ctx, "Failed to execute pending bulk operations", map[string]interface{}{
@@ -468,17 +1191,52 @@ func (p *providerContext) NotifyOperationAdded() {
 
 func authenticate(ctx context.Context, provCtx *providerContext) error {
 	token, needsRefresh := provCtx.tokenManager.GetToken()
-	if !needsRefresh {
-		u, _ := url.Parse(provCtx.config.Servers[0].URL)
-		provCtx.config.HTTPClient.Jar.SetCookies(u, []*http.Cookie{
-			{
-				Name:  "ivn_api",
-				Value: token,
-			},
+	if needsRefresh {
+		newToken, lifetime, err := requestToken(ctx, provCtx)
+		if err != nil {
+			return err
+		}
+
+		provCtx.tokenManager.SetToken(newToken, lifetime)
+		tflog.Debug(ctx, "Authenticated with Verity API", map[string]interface{}{
+			"token_lifetime": lifetime.String(),
 		})
-		return nil
+		token = newToken
+	}
+
+	u, _ := url.Parse(provCtx.config.Servers[0].URL)
+	provCtx.config.HTTPClient.Jar.SetCookies(u, []*http.Cookie{
+		{
+			Name:  authCookieName,
+			Value: token,
+		},
+	})
+
+	return nil
+}
+
+// requestToken returns a new token from the configured credentials, in order of preference:
+// api_token, the exec credential helper, then username and password.
+func requestToken(ctx context.Context, provCtx *providerContext) (string, time.Duration, error) {
+	creds := &provCtx.credentials
+
+	if creds.apiToken != "" && !creds.apiTokenRejected.Load() {
+		return creds.apiToken, defaultTokenLifetime, nil
 	}
 
+	if creds.exec != nil {
+		return creds.exec.run(ctx)
+	}
+
+	if creds.username == "" || creds.password == "" {
+		return "", 0, fmt.Errorf("api_token was rejected by the API and no username and password are configured")
+	}
+
+	return login(ctx, provCtx)
+}
+
+// login exchanges username and password for a token.
+func login(ctx context.Context, provCtx *providerContext) (string, time.Duration, error) {
 	auth := openapi.NewAuthPostRequestAuth(
 		provCtx.credentials.username,
 		provCtx.credentials.password,
@@ -488,32 +1246,72 @@ func authenticate(ctx context.Context, provCtx *providerContext) error {
 
 	resp, err := provCtx.client.AuthorizationAPI.AuthPost(ctx).AuthPostRequest(*authReq).Execute()
 	if err != nil {
-		return fmt.Errorf("failed to authenticate: %v", err)
+		return "", 0, fmt.Errorf("failed to authenticate: %v", err)
 	}
 	defer resp.Body.Close()
 
 	var result struct {
-		Token string `json:"token"`
+		Token     string `json:"token"`
+		ExpiresIn int64  `json:"expires_in"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode response: %v", err)
+		return "", 0, fmt.Errorf("failed to decode response: %v", err)
 	}
 
 	if result.Token == "" {
-		return fmt.Errorf("no token found in response")
+		return "", 0, fmt.Errorf("no token found in response")
 	}
 
-	provCtx.tokenManager.SetToken(result.Token, 24*time.Hour)
+	return result.Token, tokenLifetime(resp, result.ExpiresIn), nil
+}
 
-	u, _ := url.Parse(provCtx.config.Servers[0].URL)
-	provCtx.config.HTTPClient.Jar.SetCookies(u, []*http.Cookie{
-		{
-			Name:  "ivn_api",
-			Value: result.Token,
-		},
-	})
+// reauthenticate replaces a token the API rejected. Concurrent requests rejected with the
+// same token share one authentication; requests holding an older token get the current one.
+// Only a 401 marks api_token as rejected, since a 403 can also mean the token lacks the
+// permission for that one request.
+func reauthenticate(ctx context.Context, provCtx *providerContext, rejectedToken string, statusCode int) (string, error) {
+	provCtx.authMutex.Lock()
+	defer provCtx.authMutex.Unlock()
 
-	return nil
+	if token, needsRefresh := provCtx.tokenManager.GetToken(); !needsRefresh && token != rejectedToken {
+		return token, nil
+	}
+
+	tflog.Info(ctx, "API token was rejected, authenticating again")
+	if statusCode == http.StatusUnauthorized && rejectedToken != "" && rejectedToken == provCtx.credentials.apiToken {
+		provCtx.credentials.apiTokenRejected.Store(true)
+	}
+	provCtx.tokenManager.Clear()
+	if err := authenticate(ctx, provCtx); err != nil {
+		return "", err
+	}
+
+	token, _ := provCtx.tokenManager.GetToken()
+	return token, nil
+}
+
+// tokenLifetime returns how long a new token is valid, taken from the expires_in field of
+// the auth response or from the ivn_api cookie. Without either, defaultTokenLifetime is used.
+func tokenLifetime(resp *http.Response, expiresIn int64) time.Duration {
+	if expiresIn > 0 {
+		return time.Duration(expiresIn) * time.Second
+	}
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != authCookieName {
+			continue
+		}
+		if cookie.MaxAge > 0 {
+			return time.Duration(cookie.MaxAge) * time.Second
+		}
+		if !cookie.Expires.IsZero() {
+			if lifetime := time.Until(cookie.Expires); lifetime > 0 {
+				return lifetime
+			}
+		}
+	}
+
+	return defaultTokenLifetime
 }
 
 func ensureAuthenticated(ctx context.Context, m interface{}) error {
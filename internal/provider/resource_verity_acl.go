@@ -609,7 +609,9 @@ func (r *verityACLUnifiedResource) ModifyPlan(ctx context.Context, req resource.
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -633,6 +635,8 @@ func (r *verityACLUnifiedResource) ModifyPlan(ctx context.Context, req resource.
 		StringFields: []string{"notes"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
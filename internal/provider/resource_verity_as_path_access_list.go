@@ -602,7 +602,9 @@ func (r *verityAsPathAccessListResource) ModifyPlan(ctx context.Context, req res
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -626,4 +628,6 @@ func (r *verityAsPathAccessListResource) ModifyPlan(ctx context.Context, req res
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"notes"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
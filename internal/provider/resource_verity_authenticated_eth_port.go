@@ -710,7 +710,9 @@ func (r *verityAuthenticatedEthPortResource) ModifyPlan(ctx context.Context, req
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -738,6 +740,8 @@ func (r *verityAuthenticatedEthPortResource) ModifyPlan(ctx context.Context, req
 		StringFields: []string{"group", "port_monitoring"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
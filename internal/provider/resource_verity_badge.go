@@ -508,7 +508,9 @@ func (r *verityBadgeResource) ModifyPlan(ctx context.Context, req resource.Modif
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -529,6 +531,8 @@ func (r *verityBadgeResource) ModifyPlan(ctx context.Context, req resource.Modif
 		StringFields: []string{"notes"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -1123,7 +1123,9 @@ func (r *verityBundleResource) ModifyPlan(ctx context.Context, req resource.Modi
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1144,6 +1146,8 @@ func (r *verityBundleResource) ModifyPlan(ctx context.Context, req resource.Modi
 		BoolFields:   []string{"is_for_switch", "is_public"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
 		BlockName: "eth_port_paths",
 		ItemCount: len(plan.EthPortPaths),
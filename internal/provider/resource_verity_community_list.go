@@ -624,7 +624,9 @@ func (r *verityCommunityListResource) ModifyPlan(ctx context.Context, req resour
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -648,4 +650,6 @@ func (r *verityCommunityListResource) ModifyPlan(ctx context.Context, req resour
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"notes"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
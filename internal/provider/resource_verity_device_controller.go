@@ -19,10 +19,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &verityDeviceControllerResource{}
-	_ resource.ResourceWithConfigure   = &verityDeviceControllerResource{}
-	_ resource.ResourceWithImportState = &verityDeviceControllerResource{}
-	_ resource.ResourceWithModifyPlan  = &verityDeviceControllerResource{}
+	_ resource.Resource                   = &verityDeviceControllerResource{}
+	_ resource.ResourceWithConfigure      = &verityDeviceControllerResource{}
+	_ resource.ResourceWithImportState    = &verityDeviceControllerResource{}
+	_ resource.ResourceWithModifyPlan     = &verityDeviceControllerResource{}
+	_ resource.ResourceWithValidateConfig = &verityDeviceControllerResource{}
 )
 
 const deviceControllerResourceType = "devicecontrollers"
@@ -74,6 +75,16 @@ type verityDeviceControllerResourceModel struct {
 	SshKeyOrPasswordEncrypted types.String `tfsdk:"ssh_key_or_password_encrypted"`
 	PassphraseEncrypted       types.String `tfsdk:"passphrase_encrypted"`
 	PrivatePasswordEncrypted  types.String `tfsdk:"private_password_encrypted"`
+	PasswordWo                types.String `tfsdk:"password_wo"`
+	PasswordWoVersion         types.Int64  `tfsdk:"password_wo_version"`
+	EnablePasswordWo          types.String `tfsdk:"enable_password_wo"`
+	EnablePasswordWoVersion   types.Int64  `tfsdk:"enable_password_wo_version"`
+	SshKeyOrPasswordWo        types.String `tfsdk:"ssh_key_or_password_wo"`
+	SshKeyOrPasswordWoVersion types.Int64  `tfsdk:"ssh_key_or_password_wo_version"`
+	PassphraseWo              types.String `tfsdk:"passphrase_wo"`
+	PassphraseWoVersion       types.Int64  `tfsdk:"passphrase_wo_version"`
+	PrivatePasswordWo         types.String `tfsdk:"private_password_wo"`
+	PrivatePasswordWoVersion  types.Int64  `tfsdk:"private_password_wo_version"`
 	DeviceManagedAs           types.String `tfsdk:"device_managed_as"`
 	Switch                    types.String `tfsdk:"switch"`
 	SwitchRefType             types.String `tfsdk:"switch_ref_type_"`
@@ -203,16 +214,19 @@ func (r *verityDeviceControllerResource) Schema(_ context.Context, _ resource.Sc
 				Description: "Password",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"enable_password": schema.StringAttribute{
 				Description: "Enable Password - to enable privileged CLI operations",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"ssh_key_or_password": schema.StringAttribute{
 				Description: "SSH Key or Password",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"managed_on_native_vlan": schema.BoolAttribute{
 				Description: "Managed on native VLAN",
@@ -253,6 +267,7 @@ func (r *verityDeviceControllerResource) Schema(_ context.Context, _ resource.Sc
 				Description: "Passphrase",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"private_protocol": schema.StringAttribute{
 				Description: "Private Protocol",
@@ -263,6 +278,7 @@ func (r *verityDeviceControllerResource) Schema(_ context.Context, _ resource.Sc
 				Description: "Private Password",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"password_encrypted": schema.StringAttribute{
 				Description: "Encrypted Password",
@@ -289,6 +305,56 @@ func (r *verityDeviceControllerResource) Schema(_ context.Context, _ resource.Sc
 				Optional:    true,
 				Computed:    true,
 			},
+			"password_wo": schema.StringAttribute{
+				Description: "Write-only Password. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with password.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description: "Version of password_wo. Required with password_wo. Change it to send a new password_wo value on update.",
+				Optional:    true,
+			},
+			"enable_password_wo": schema.StringAttribute{
+				Description: "Write-only Enable Password. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with enable_password.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"enable_password_wo_version": schema.Int64Attribute{
+				Description: "Version of enable_password_wo. Required with enable_password_wo. Change it to send a new enable_password_wo value on update.",
+				Optional:    true,
+			},
+			"ssh_key_or_password_wo": schema.StringAttribute{
+				Description: "Write-only SSH Key or Password. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with ssh_key_or_password.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"ssh_key_or_password_wo_version": schema.Int64Attribute{
+				Description: "Version of ssh_key_or_password_wo. Required with ssh_key_or_password_wo. Change it to send a new ssh_key_or_password_wo value on update.",
+				Optional:    true,
+			},
+			"passphrase_wo": schema.StringAttribute{
+				Description: "Write-only Passphrase. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with passphrase.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"passphrase_wo_version": schema.Int64Attribute{
+				Description: "Version of passphrase_wo. Required with passphrase_wo. Change it to send a new passphrase_wo value on update.",
+				Optional:    true,
+			},
+			"private_password_wo": schema.StringAttribute{
+				Description: "Write-only Private Password. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with private_password.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"private_password_wo_version": schema.Int64Attribute{
+				Description: "Version of private_password_wo. Required with private_password_wo. Change it to send a new private_password_wo value on update.",
+				Optional:    true,
+			},
 			"device_managed_as": schema.StringAttribute{
 				Description: "Device managed as",
 				Optional:    true,
@@ -341,6 +407,14 @@ func (r *verityDeviceControllerResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	// Write-only values are only available in the config
+	var config verityDeviceControllerResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to Authenticate",
@@ -404,6 +478,13 @@ func (r *verityDeviceControllerResource) Create(ctx context.Context, req resourc
 		{FieldName: "UsesTaggedPackets", APIField: &deviceControllerProps.UsesTaggedPackets, TFValue: plan.UsesTaggedPackets},
 	})
 
+	// Handle write-only credential fields
+	utils.SetWriteOnlyStringField(config.PasswordWo, func(v *string) { deviceControllerProps.Password = v })
+	utils.SetWriteOnlyStringField(config.EnablePasswordWo, func(v *string) { deviceControllerProps.EnablePassword = v })
+	utils.SetWriteOnlyStringField(config.SshKeyOrPasswordWo, func(v *string) { deviceControllerProps.SshKeyOrPassword = v })
+	utils.SetWriteOnlyStringField(config.PassphraseWo, func(v *string) { deviceControllerProps.Passphrase = v })
+	utils.SetWriteOnlyStringField(config.PrivatePasswordWo, func(v *string) { deviceControllerProps.PrivatePassword = v })
+
 	success := bulkops.ExecuteResourceOperation(ctx, r.bulkOpsMgr, r.notifyOperationAdded, "create", "device_controller", name, *deviceControllerProps, &resp.Diagnostics)
 	if !success {
 		return
@@ -414,6 +495,7 @@ func (r *verityDeviceControllerResource) Create(ctx context.Context, req resourc
 
 	var minState verityDeviceControllerResourceModel
 	minState.Name = types.StringValue(name)
+	copyDeviceControllerWriteOnlyVersions(&minState, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &minState)...)
 
 	if resp.Diagnostics.HasError() {
@@ -556,6 +638,14 @@ func (r *verityDeviceControllerResource) Update(ctx context.Context, req resourc
 		return
 	}
 
+	// Write-only values are only available in the config
+	var config verityDeviceControllerResourceModel
+	diags = req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to Authenticate",
@@ -609,6 +699,13 @@ func (r *verityDeviceControllerResource) Update(ctx context.Context, req resourc
 	utils.CompareAndSetBoolField(plan.ManagedOnNativeVlan, state.ManagedOnNativeVlan, func(v *bool) { deviceControllerProps.ManagedOnNativeVlan = v }, &hasChanges)
 	utils.CompareAndSetBoolField(plan.UsesTaggedPackets, state.UsesTaggedPackets, func(v *bool) { deviceControllerProps.UsesTaggedPackets = v }, &hasChanges)
 
+	// Handle write-only credential fields, which are only sent when their version changes
+	utils.CompareAndSetWriteOnlyStringField(config.PasswordWo, plan.PasswordWoVersion, state.PasswordWoVersion, func(v *string) { deviceControllerProps.Password = v }, &hasChanges)
+	utils.CompareAndSetWriteOnlyStringField(config.EnablePasswordWo, plan.EnablePasswordWoVersion, state.EnablePasswordWoVersion, func(v *string) { deviceControllerProps.EnablePassword = v }, &hasChanges)
+	utils.CompareAndSetWriteOnlyStringField(config.SshKeyOrPasswordWo, plan.SshKeyOrPasswordWoVersion, state.SshKeyOrPasswordWoVersion, func(v *string) { deviceControllerProps.SshKeyOrPassword = v }, &hasChanges)
+	utils.CompareAndSetWriteOnlyStringField(config.PassphraseWo, plan.PassphraseWoVersion, state.PassphraseWoVersion, func(v *string) { deviceControllerProps.Passphrase = v }, &hasChanges)
+	utils.CompareAndSetWriteOnlyStringField(config.PrivatePasswordWo, plan.PrivatePasswordWoVersion, state.PrivatePasswordWoVersion, func(v *string) { deviceControllerProps.PrivatePassword = v }, &hasChanges)
+
 	// Handle Switchpoint and SwitchpointRefType using "One ref type supported" pattern
 	if !utils.HandleOneRefTypeSupported(
 		plan.Switchpoint, state.Switchpoint, plan.SwitchpointRefType, state.SwitchpointRefType,
@@ -660,6 +757,7 @@ func (r *verityDeviceControllerResource) Update(ctx context.Context, req resourc
 
 	var minState verityDeviceControllerResourceModel
 	minState.Name = types.StringValue(name)
+	copyDeviceControllerWriteOnlyVersions(&minState, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &minState)...)
 
 	if resp.Diagnostics.HasError() {
@@ -745,18 +843,29 @@ func populateDeviceControllerState(ctx context.Context, state verityDeviceContro
 	state.CommunicationMode = utils.MapStringWithMode(data, "communication_mode", resourceType, mode)
 	state.CliAccessMode = utils.MapStringWithMode(data, "cli_access_mode", resourceType, mode)
 	state.Username = utils.MapStringWithMode(data, "username", resourceType, mode)
-	state.Password = utils.MapStringWithMode(data, "password", resourceType, mode)
-	state.EnablePassword = utils.MapStringWithMode(data, "enable_password", resourceType, mode)
-	state.SshKeyOrPassword = utils.MapStringWithMode(data, "ssh_key_or_password", resourceType, mode)
+	// credentials set through their write-only companions are not read back into state
+	if state.PasswordWoVersion.IsNull() {
+		state.Password = utils.MapStringWithMode(data, "password", resourceType, mode)
+	}
+	if state.EnablePasswordWoVersion.IsNull() {
+		state.EnablePassword = utils.MapStringWithMode(data, "enable_password", resourceType, mode)
+	}
+	if state.SshKeyOrPasswordWoVersion.IsNull() {
+		state.SshKeyOrPassword = utils.MapStringWithMode(data, "ssh_key_or_password", resourceType, mode)
+	}
 	state.Sdlc = utils.MapStringWithMode(data, "sdlc", resourceType, mode)
 	state.Switchpoint = utils.MapStringWithMode(data, "switchpoint", resourceType, mode)
 	state.SwitchpointRefType = utils.MapStringWithMode(data, "switchpoint_ref_type_", resourceType, mode)
 	state.SecurityType = utils.MapStringWithMode(data, "security_type", resourceType, mode)
 	state.Snmpv3Username = utils.MapStringWithMode(data, "snmpv3_username", resourceType, mode)
 	state.AuthenticationProtocol = utils.MapStringWithMode(data, "authentication_protocol", resourceType, mode)
-	state.Passphrase = utils.MapStringWithMode(data, "passphrase", resourceType, mode)
+	if state.PassphraseWoVersion.IsNull() {
+		state.Passphrase = utils.MapStringWithMode(data, "passphrase", resourceType, mode)
+	}
 	state.PrivateProtocol = utils.MapStringWithMode(data, "private_protocol", resourceType, mode)
-	state.PrivatePassword = utils.MapStringWithMode(data, "private_password", resourceType, mode)
+	if state.PrivatePasswordWoVersion.IsNull() {
+		state.PrivatePassword = utils.MapStringWithMode(data, "private_password", resourceType, mode)
+	}
 	state.PasswordEncrypted = utils.MapStringWithMode(data, "password_encrypted", resourceType, mode)
 	state.EnablePasswordEncrypted = utils.MapStringWithMode(data, "enable_password_encrypted", resourceType, mode)
 	state.SshKeyOrPasswordEncrypted = utils.MapStringWithMode(data, "ssh_key_or_password_encrypted", resourceType, mode)
@@ -773,6 +882,22 @@ func populateDeviceControllerState(ctx context.Context, state verityDeviceContro
 	return state
 }
 
+// copyDeviceControllerWriteOnlyVersions carries the write-only versions over to a state that is
+// rebuilt from the API response, which does not know about them.
+func copyDeviceControllerWriteOnlyVersions(dst *verityDeviceControllerResourceModel, src verityDeviceControllerResourceModel) {
+	dst.PasswordWoVersion = src.PasswordWoVersion
+	dst.EnablePasswordWoVersion = src.EnablePasswordWoVersion
+	dst.SshKeyOrPasswordWoVersion = src.SshKeyOrPasswordWoVersion
+	dst.PassphraseWoVersion = src.PassphraseWoVersion
+	dst.PrivatePasswordWoVersion = src.PrivatePasswordWoVersion
+}
+
+func (r *verityDeviceControllerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	utils.ValidateWriteOnlyFields(ctx, req.Config, []string{
+		"password", "enable_password", "ssh_key_or_password", "passphrase", "private_password",
+	}, &resp.Diagnostics)
+}
+
 func (r *verityDeviceControllerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// =========================================================================
 	// Skip if deleting
@@ -799,7 +924,9 @@ func (r *verityDeviceControllerResource) ModifyPlan(ctx context.Context, req res
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -818,4 +945,37 @@ func (r *verityDeviceControllerResource) ModifyPlan(ctx context.Context, req res
 	nullifier.NullifyBools(
 		"enable", "managed_on_native_vlan", "uses_tagged_packets",
 	)
+
+	// =========================================================================
+	// Skip UPDATE-specific logic during CREATE
+	// =========================================================================
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// =========================================================================
+	// UPDATE operation - get state and config
+	// =========================================================================
+	var state verityDeviceControllerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config verityDeviceControllerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// =========================================================================
+	// Credentials set through their write-only companions
+	// =========================================================================
+	utils.NullPlanForWriteOnlyFields(ctx, &resp.Plan, []utils.WriteOnlyStringField{
+		{AttrName: "password", Version: config.PasswordWoVersion},
+		{AttrName: "enable_password", Version: config.EnablePasswordWoVersion},
+		{AttrName: "ssh_key_or_password", Version: config.SshKeyOrPasswordWoVersion},
+		{AttrName: "passphrase", Version: config.PassphraseWoVersion},
+		{AttrName: "private_password", Version: config.PrivatePasswordWoVersion},
+	}, &resp.Diagnostics)
 }
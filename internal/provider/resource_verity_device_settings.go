@@ -634,7 +634,9 @@ func (r *verityDeviceSettingsResource) ModifyPlan(ctx context.Context, req resou
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -662,6 +664,8 @@ func (r *verityDeviceSettingsResource) ModifyPlan(ctx context.Context, req resou
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
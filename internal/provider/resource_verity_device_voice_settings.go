@@ -1160,7 +1160,9 @@ func (r *verityDeviceVoiceSettingsResource) ModifyPlan(ctx context.Context, req
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1204,6 +1206,8 @@ func (r *verityDeviceVoiceSettingsResource) ModifyPlan(ctx context.Context, req
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -403,7 +403,9 @@ func (r *verityDiagnosticsPortProfileResource) ModifyPlan(ctx context.Context, r
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
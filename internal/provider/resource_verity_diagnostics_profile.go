@@ -486,7 +486,9 @@ func (r *verityDiagnosticsProfileResource) ModifyPlan(ctx context.Context, req r
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -893,7 +893,9 @@ func (r *verityEthPortProfileResource) ModifyPlan(ctx context.Context, req resou
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -913,6 +915,8 @@ func (r *verityEthPortProfileResource) ModifyPlan(ctx context.Context, req resou
 		BoolFields:   []string{"sort_by_name"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
 		BlockName: "services",
 		ItemCount: len(plan.Services),
@@ -1077,7 +1077,9 @@ func (r *verityEthPortSettingsResource) ModifyPlan(ctx context.Context, req reso
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1112,6 +1114,8 @@ func (r *verityEthPortSettingsResource) ModifyPlan(ctx context.Context, req reso
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
 		BlockName:    "lldp_med",
 		ItemCount:    len(plan.LldpMed),
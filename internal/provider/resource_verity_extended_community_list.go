@@ -628,7 +628,9 @@ func (r *verityExtendedCommunityListResource) ModifyPlan(ctx context.Context, re
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -652,4 +654,6 @@ func (r *verityExtendedCommunityListResource) ModifyPlan(ctx context.Context, re
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"notes"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
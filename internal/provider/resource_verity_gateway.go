@@ -19,10 +19,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = &verityGatewayResource{}
-	_ resource.ResourceWithConfigure   = &verityGatewayResource{}
-	_ resource.ResourceWithImportState = &verityGatewayResource{}
-	_ resource.ResourceWithModifyPlan  = &verityGatewayResource{}
+	_ resource.Resource                   = &verityGatewayResource{}
+	_ resource.ResourceWithConfigure      = &verityGatewayResource{}
+	_ resource.ResourceWithImportState    = &verityGatewayResource{}
+	_ resource.ResourceWithModifyPlan     = &verityGatewayResource{}
+	_ resource.ResourceWithValidateConfig = &verityGatewayResource{}
 )
 
 const gatewayResourceType = "gateways"
@@ -59,6 +60,8 @@ type verityGatewayResourceModel struct {
 	Md5Password                types.String                         `tfsdk:"md5_password"`
 	Md5PasswordEncrypted       types.String                         `tfsdk:"md5_password_encrypted"`
 	SwitchEncryptedMd5Password types.Bool                           `tfsdk:"switch_encrypted_md5_password"`
+	Md5PasswordWo              types.String                         `tfsdk:"md5_password_wo"`
+	Md5PasswordWoVersion       types.Int64                          `tfsdk:"md5_password_wo_version"`
 	ImportRouteMap             types.String                         `tfsdk:"import_route_map"`
 	StaticRoutes               []verityGatewayStaticRoutesModel     `tfsdk:"static_routes"`
 	ExportRouteMap             types.String                         `tfsdk:"export_route_map"`
@@ -206,6 +209,17 @@ func (r *verityGatewayResource) Schema(ctx context.Context, req resource.SchemaR
 				Description: "MD5 Password used in the BGP session",
 				Optional:    true,
 				Computed:    true,
+				Sensitive:   true,
+			},
+			"md5_password_wo": schema.StringAttribute{
+				Description: "Write-only MD5 Password used in the BGP session. Sent to the API but never stored in the plan or state. Requires Terraform 1.11 or later. Conflicts with md5_password.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+			},
+			"md5_password_wo_version": schema.Int64Attribute{
+				Description: "Version of md5_password_wo. Change it to send a new md5_password_wo value on update.",
+				Optional:    true,
 			},
 			"md5_password_encrypted": schema.StringAttribute{
 				Description: "MD5 Password Encrypted used in the BGP session",
@@ -426,6 +440,9 @@ func (r *verityGatewayResource) Create(ctx context.Context, req resource.CreateR
 		{FieldName: "SwitchEncryptedMd5Password", APIField: &gatewayProps.SwitchEncryptedMd5Password, TFValue: plan.SwitchEncryptedMd5Password},
 	})
 
+	// Handle write-only fields, which are only available in the config
+	utils.SetWriteOnlyStringField(config.Md5PasswordWo, func(v *string) { gatewayProps.Md5Password = v })
+
 	// Handle nullable int64 fields - parse HCL to detect explicit config
 	workDir := r.provCtx.workDir
 	configuredAttrs := utils.ParseResourceConfiguredAttributes(ctx, workDir, gatewayTerraformType, name)
@@ -494,6 +511,7 @@ func (r *verityGatewayResource) Create(ctx context.Context, req resource.CreateR
 
 	var minState verityGatewayResourceModel
 	minState.Name = types.StringValue(name)
+	minState.Md5PasswordWoVersion = plan.Md5PasswordWoVersion
 	resp.Diagnostics.Append(resp.State.Set(ctx, &minState)...)
 
 	if resp.Diagnostics.HasError() {
@@ -682,6 +700,9 @@ func (r *verityGatewayResource) Update(ctx context.Context, req resource.UpdateR
 	utils.CompareAndSetBoolField(plan.DefaultOriginate, state.DefaultOriginate, func(v *bool) { gatewayProps.DefaultOriginate = v }, &hasChanges)
 	utils.CompareAndSetBoolField(plan.SwitchEncryptedMd5Password, state.SwitchEncryptedMd5Password, func(v *bool) { gatewayProps.SwitchEncryptedMd5Password = v }, &hasChanges)
 
+	// Handle write-only field changes, which are only sent when their version changes
+	utils.CompareAndSetWriteOnlyStringField(config.Md5PasswordWo, plan.Md5PasswordWoVersion, state.Md5PasswordWoVersion, func(v *string) { gatewayProps.Md5Password = v }, &hasChanges)
+
 	// Handle nullable int64 field changes - parse HCL to detect explicit config
 	utils.CompareAndSetNullableInt64Field(config.NeighborAsNumber, state.NeighborAsNumber, configuredAttrs.IsConfigured("neighbor_as_number"), func(v *openapi.NullableInt32) { gatewayProps.NeighborAsNumber = *v }, &hasChanges)
 	utils.CompareAndSetNullableInt64Field(config.KeepaliveTimer, state.KeepaliveTimer, configuredAttrs.IsConfigured("keepalive_timer"), func(v *openapi.NullableInt32) { gatewayProps.KeepaliveTimer = *v }, &hasChanges)
@@ -831,6 +852,7 @@ func (r *verityGatewayResource) Update(ctx context.Context, req resource.UpdateR
 
 	var minState verityGatewayResourceModel
 	minState.Name = types.StringValue(name)
+	minState.Md5PasswordWoVersion = plan.Md5PasswordWoVersion
 	resp.Diagnostics.Append(resp.State.Set(ctx, &minState)...)
 
 	if resp.Diagnostics.HasError() {
@@ -928,7 +950,10 @@ func populateGatewayState(ctx context.Context, state verityGatewayResourceModel,
 	state.NeighborIpAddress = utils.MapStringWithMode(data, "neighbor_ip_address", resourceType, mode)
 	state.SourceIpAddress = utils.MapStringWithMode(data, "source_ip_address", resourceType, mode)
 	state.AnycastIpMask = utils.MapStringWithMode(data, "anycast_ip_mask", resourceType, mode)
-	state.Md5Password = utils.MapStringWithMode(data, "md5_password", resourceType, mode)
+	// a password set through md5_password_wo is not read back into state
+	if state.Md5PasswordWoVersion.IsNull() {
+		state.Md5Password = utils.MapStringWithMode(data, "md5_password", resourceType, mode)
+	}
 	state.Md5PasswordEncrypted = utils.MapStringWithMode(data, "md5_password_encrypted", resourceType, mode)
 	state.ImportRouteMap = utils.MapStringWithMode(data, "import_route_map", resourceType, mode)
 	state.ExportRouteMap = utils.MapStringWithMode(data, "export_route_map", resourceType, mode)
@@ -985,6 +1010,10 @@ func populateGatewayState(ctx context.Context, state verityGatewayResourceModel,
 	return state
 }
 
+func (r *verityGatewayResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	utils.ValidateWriteOnlyFields(ctx, req.Config, []string{"md5_password"}, &resp.Diagnostics)
+}
+
 func (r *verityGatewayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// =========================================================================
 	// Skip if deleting
@@ -1011,7 +1040,9 @@ func (r *verityGatewayResource) ModifyPlan(ctx context.Context, req resource.Mod
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1050,6 +1081,8 @@ func (r *verityGatewayResource) ModifyPlan(ctx context.Context, req resource.Mod
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -1103,6 +1136,13 @@ func (r *verityGatewayResource) ModifyPlan(ctx context.Context, req resource.Mod
 		},
 	})
 
+	// =========================================================================
+	// Credentials set through their write-only companions
+	// =========================================================================
+	utils.NullPlanForWriteOnlyFields(ctx, &resp.Plan, []utils.WriteOnlyStringField{
+		{AttrName: "md5_password", Version: config.Md5PasswordWoVersion},
+	}, &resp.Diagnostics)
+
 	// =========================================================================
 	// Handle nullable fields in nested blocks
 	// =========================================================================
@@ -627,7 +627,9 @@ func (r *verityGatewayProfileResource) ModifyPlan(ctx context.Context, req resou
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -647,4 +649,6 @@ func (r *verityGatewayProfileResource) ModifyPlan(ctx context.Context, req resou
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"group"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
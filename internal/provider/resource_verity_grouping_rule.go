@@ -604,7 +604,9 @@ func (r *verityGroupingRuleResource) ModifyPlan(ctx context.Context, req resourc
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
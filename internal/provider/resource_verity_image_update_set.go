@@ -0,0 +1,873 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-verity/internal/bulkops"
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/openapi"
+)
+
+var (
+	_ resource.Resource                = &verityImageUpdateSetResource{}
+	_ resource.ResourceWithConfigure   = &verityImageUpdateSetResource{}
+	_ resource.ResourceWithImportState = &verityImageUpdateSetResource{}
+	_ resource.ResourceWithModifyPlan  = &verityImageUpdateSetResource{}
+)
+
+const imageUpdateSetResourceType = "imageupdatesets"
+
+func NewVerityImageUpdateSetResource() resource.Resource {
+	return &verityImageUpdateSetResource{}
+}
+
+type verityImageUpdateSetResource struct {
+	provCtx              *providerContext
+	client               *openapi.APIClient
+	bulkOpsMgr           *bulkops.Manager
+	notifyOperationAdded func()
+}
+
+type verityImageUpdateSetResourceModel struct {
+	Name                  types.String                                `tfsdk:"name"`
+	Enable                types.Bool                                  `tfsdk:"enable"`
+	UpgraderOnSummary     types.Bool                                  `tfsdk:"upgrader_on_summary"`
+	InstallationOnSummary types.Bool                                  `tfsdk:"installation_on_summary"`
+	CommOnSummary         types.Bool                                  `tfsdk:"comm_on_summary"`
+	ProvisioningOnSummary types.Bool                                  `tfsdk:"provisioning_on_summary"`
+	Type                  types.String                                `tfsdk:"type"`
+	Section               []verityImageUpdateSetSectionModel          `tfsdk:"section"`
+	SectionElse           []verityImageUpdateSetSectionElseModel      `tfsdk:"section_else"`
+	SectionPointless      []verityImageUpdateSetSectionPointlessModel `tfsdk:"section_pointless"`
+	ObjectProperties      []verityImageUpdateSetObjectPropertiesModel `tfsdk:"object_properties"`
+}
+
+type verityImageUpdateSetSectionModel struct {
+	EndpointSetNumName                         types.String `tfsdk:"endpoint_set_num_name"`
+	EndpointSetNumTargetUpgradeVersion         types.String `tfsdk:"endpoint_set_num_target_upgrade_version"`
+	EndpointSetNumUniqueIdentifier             types.String `tfsdk:"endpoint_set_num_unique_identifier"`
+	EndpointSetNumOnSummary                    types.Bool   `tfsdk:"endpoint_set_num_on_summary"`
+	EndpointSetNumTargetUpgradeVersionTime     types.String `tfsdk:"endpoint_set_num_target_upgrade_version_time"`
+	EndpointSetNumSubrule1Inverted             types.Bool   `tfsdk:"endpoint_set_num_subrule_1_inverted"`
+	EndpointSetNumSubrule1Type                 types.String `tfsdk:"endpoint_set_num_subrule_1_type"`
+	EndpointSetNumSubrule1Value                types.String `tfsdk:"endpoint_set_num_subrule_1_value"`
+	EndpointSetNumSubrule1ReferencePath        types.String `tfsdk:"endpoint_set_num_subrule_1_reference_path"`
+	EndpointSetNumSubrule1ReferencePathRefType types.String `tfsdk:"endpoint_set_num_subrule_1_reference_path_ref_type_"`
+	EndpointSetNumSubrule2Inverted             types.Bool   `tfsdk:"endpoint_set_num_subrule_2_inverted"`
+	EndpointSetNumSubrule2Type                 types.String `tfsdk:"endpoint_set_num_subrule_2_type"`
+	EndpointSetNumSubrule2Value                types.String `tfsdk:"endpoint_set_num_subrule_2_value"`
+	EndpointSetNumSubrule2ReferencePath        types.String `tfsdk:"endpoint_set_num_subrule_2_reference_path"`
+	EndpointSetNumSubrule2ReferencePathRefType types.String `tfsdk:"endpoint_set_num_subrule_2_reference_path_ref_type_"`
+	EndpointSetNumSubrule3Inverted             types.Bool   `tfsdk:"endpoint_set_num_subrule_3_inverted"`
+	EndpointSetNumSubrule3Type                 types.String `tfsdk:"endpoint_set_num_subrule_3_type"`
+	EndpointSetNumSubrule3Value                types.String `tfsdk:"endpoint_set_num_subrule_3_value"`
+	EndpointSetNumSubrule3ReferencePath        types.String `tfsdk:"endpoint_set_num_subrule_3_reference_path"`
+	EndpointSetNumSubrule3ReferencePathRefType types.String `tfsdk:"endpoint_set_num_subrule_3_reference_path_ref_type_"`
+}
+
+type verityImageUpdateSetSectionElseModel struct {
+	EndpointSetNumName                              types.String `tfsdk:"endpoint_set_num_name"`
+	EndpointSetForAllOthersTargetUpgradeVersion     types.String `tfsdk:"endpoint_set_for_all_others_target_upgrade_version"`
+	EndpointSetForAllOthersUniqueIdentifier         types.String `tfsdk:"endpoint_set_for_all_others_unique_identifier"`
+	EndpointSetNumOnSummary                         types.Bool   `tfsdk:"endpoint_set_num_on_summary"`
+	EndpointSetForAllOthersTargetUpgradeVersionTime types.String `tfsdk:"endpoint_set_for_all_others_target_upgrade_version_time"`
+}
+
+type verityImageUpdateSetSectionPointlessModel struct {
+	EndpointSetNumName                                 types.String `tfsdk:"endpoint_set_num_name"`
+	EndpointSetForEndpointlessTargetUpgradeVersion     types.String `tfsdk:"endpoint_set_for_endpointless_target_upgrade_version"`
+	EndpointSetForEndpointlessUniqueIdentifier         types.String `tfsdk:"endpoint_set_for_endpointless_unique_identifier"`
+	EndpointSetNumOnSummary                            types.Bool   `tfsdk:"endpoint_set_num_on_summary"`
+	EndpointSetForEndpointlessTargetUpgradeVersionTime types.String `tfsdk:"endpoint_set_for_endpointless_target_upgrade_version_time"`
+}
+
+type verityImageUpdateSetObjectPropertiesModel struct {
+	FirmwareCount types.Int64 `tfsdk:"firmware_count"`
+}
+
+func (r *verityImageUpdateSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_image_update_set"
+}
+
+func (r *verityImageUpdateSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provCtx, ok := req.ProviderData.(*providerContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.provCtx = provCtx
+	r.client = provCtx.client
+	r.bulkOpsMgr = provCtx.bulkOpsMgr
+	r.notifyOperationAdded = provCtx.NotifyOperationAdded
+}
+
+func (r *verityImageUpdateSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Verity Image Update Set",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Object Name. Must be unique.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enable": schema.BoolAttribute{
+				Description: "Enable object.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"upgrader_on_summary": schema.BoolAttribute{
+				Description: "Show Upgrader Pie Chart on Summary",
+				Optional:    true,
+				Computed:    true,
+			},
+			"installation_on_summary": schema.BoolAttribute{
+				Description: "Show Installation Pie Chart on Summary",
+				Optional:    true,
+				Computed:    true,
+			},
+			"comm_on_summary": schema.BoolAttribute{
+				Description: "Show Comm Pie Chart on Summary",
+				Optional:    true,
+				Computed:    true,
+			},
+			"provisioning_on_summary": schema.BoolAttribute{
+				Description: "Show Provisioning Pie Chart on Summary",
+				Optional:    true,
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of Image Update Sets",
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"section": schema.ListNestedBlock{
+				Description: "Endpoint Sets selected by subrules",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_set_num_name": schema.StringAttribute{
+							Description: "The name of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_target_upgrade_version": schema.StringAttribute{
+							Description: "The target SW version for member devices of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_unique_identifier": schema.StringAttribute{
+							Description: "Unique Identifier - not editable",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_on_summary": schema.BoolAttribute{
+							Description: "Include on the Summary",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_target_upgrade_version_time": schema.StringAttribute{
+							Description: "The time to update to the target SW version",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_1_inverted": schema.BoolAttribute{
+							Description: "Subrule 1 Inverted of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_1_type": schema.StringAttribute{
+							Description: "Subrule 1 Type of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_1_value": schema.StringAttribute{
+							Description: "Subrule 1 Value of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_1_reference_path": schema.StringAttribute{
+							Description: "Subrule 1 Reference Path of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_1_reference_path_ref_type_": schema.StringAttribute{
+							Description: "Object type for endpoint_set_num_subrule_1_reference_path field",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_2_inverted": schema.BoolAttribute{
+							Description: "Subrule 2 Inverted of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_2_type": schema.StringAttribute{
+							Description: "Subrule 2 Type of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_2_value": schema.StringAttribute{
+							Description: "Subrule 2 Value of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_2_reference_path": schema.StringAttribute{
+							Description: "Subrule 2 Reference Path of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_2_reference_path_ref_type_": schema.StringAttribute{
+							Description: "Object type for endpoint_set_num_subrule_2_reference_path field",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_3_inverted": schema.BoolAttribute{
+							Description: "Subrule 3 Inverted of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_3_type": schema.StringAttribute{
+							Description: "Subrule 3 Type of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_3_value": schema.StringAttribute{
+							Description: "Subrule 3 Value of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_3_reference_path": schema.StringAttribute{
+							Description: "Subrule 3 Reference Path of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_subrule_3_reference_path_ref_type_": schema.StringAttribute{
+							Description: "Object type for endpoint_set_num_subrule_3_reference_path field",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"section_else": schema.ListNestedBlock{
+				Description: "Endpoint Set for all other devices",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_set_num_name": schema.StringAttribute{
+							Description: "The name of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_all_others_target_upgrade_version": schema.StringAttribute{
+							Description: "The target SW version for member devices of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_all_others_unique_identifier": schema.StringAttribute{
+							Description: "Unique Identifier - not editable",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_on_summary": schema.BoolAttribute{
+							Description: "Include on the Summary",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_all_others_target_upgrade_version_time": schema.StringAttribute{
+							Description: "The time to update to the target SW version",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"section_pointless": schema.ListNestedBlock{
+				Description: "Endpoint Set for devices without an endpoint",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"endpoint_set_num_name": schema.StringAttribute{
+							Description: "The name of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_endpointless_target_upgrade_version": schema.StringAttribute{
+							Description: "The target SW version for member devices of the Endpoint Set",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_endpointless_unique_identifier": schema.StringAttribute{
+							Description: "Unique Identifier - not editable",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_num_on_summary": schema.BoolAttribute{
+							Description: "Include on the Summary",
+							Optional:    true,
+							Computed:    true,
+						},
+						"endpoint_set_for_endpointless_target_upgrade_version_time": schema.StringAttribute{
+							Description: "The time to update to the target SW version",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"object_properties": schema.ListNestedBlock{
+				Description: "Object properties for the image update set",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"firmware_count": schema.Int64Attribute{
+							Description: "Firmware Count",
+							Optional:    true,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *verityImageUpdateSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.AddError(
+		"Create Not Supported",
+		"Image Update Set resources cannot be created. They represent existing image update sets that can only be read and updated.",
+	)
+}
+
+func (r *verityImageUpdateSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state verityImageUpdateSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return
+	}
+
+	imageUpdateSetName := state.Name.ValueString()
+
+	// Check for cached data from recent operations first
+	if r.bulkOpsMgr != nil {
+		if imageUpdateSetData, exists := r.bulkOpsMgr.GetResourceResponse("image_update_set", imageUpdateSetName); exists {
+			tflog.Info(ctx, fmt.Sprintf("Using cached image_update_set data for %s from recent operation", imageUpdateSetName))
+			state = populateImageUpdateSetState(ctx, state, imageUpdateSetData, r.provCtx.mode)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+
+	if r.bulkOpsMgr != nil && r.bulkOpsMgr.HasPendingOrRecentOperations("image_update_set") {
+		tflog.Info(ctx, fmt.Sprintf("Skipping Image Update Set %s verification – trusting recent successful API operation", imageUpdateSetName))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Fetching Image Update Sets for verification of %s", imageUpdateSetName))
+
+	type ImageUpdateSetsResponse struct {
+		ImageUpdateSets map[string]interface{} `json:"image_update_sets"`
+	}
+
+	result, err := utils.FetchResourceWithRetry(ctx, r.provCtx, "image_update_sets", imageUpdateSetName,
+		func() (ImageUpdateSetsResponse, error) {
+			tflog.Debug(ctx, "Making API call to fetch Image Update Sets")
+			respAPI, err := r.client.ImageUpdateSetsAPI.ImageupdatesetsGet(ctx).Execute()
+			if err != nil {
+				return ImageUpdateSetsResponse{}, fmt.Errorf("error reading Image Update Sets: %v", err)
+			}
+			defer respAPI.Body.Close()
+
+			var res ImageUpdateSetsResponse
+			if err := json.NewDecoder(respAPI.Body).Decode(&res); err != nil {
+				return ImageUpdateSetsResponse{}, fmt.Errorf("failed to decode Image Update Sets response: %v", err)
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Successfully fetched %d Image Update Sets", len(res.ImageUpdateSets)))
+			return res, nil
+		},
+		getCachedResponse,
+	)
+	if err != nil {
+		resp.Diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to Read Image Update Set %s", imageUpdateSetName))...,
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking for Image Update Set with name: %s", imageUpdateSetName))
+
+	imageUpdateSetData, actualAPIName, exists := utils.FindResourceByAPIName(
+		result.ImageUpdateSets,
+		imageUpdateSetName,
+		func(data interface{}) (string, bool) {
+			if imageUpdateSet, ok := data.(map[string]interface{}); ok {
+				if name, ok := imageUpdateSet["name"].(string); ok {
+					return name, true
+				}
+			}
+			return "", false
+		},
+	)
+
+	if !exists {
+		tflog.Debug(ctx, fmt.Sprintf("Image Update Set with name '%s' not found in API response", imageUpdateSetName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	imageUpdateSetMap, ok := imageUpdateSetData.(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Image Update Set Data",
+			fmt.Sprintf("Image Update Set data is not in expected format for %s", imageUpdateSetName),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found Image Update Set '%s' under API key '%s'", imageUpdateSetName, actualAPIName))
+
+	state = populateImageUpdateSetState(ctx, state, imageUpdateSetMap, r.provCtx.mode)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *verityImageUpdateSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state verityImageUpdateSetResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return
+	}
+
+	name := plan.Name.ValueString()
+	imageUpdateSetProps := openapi.ImageupdatesetsPatchRequestImageUpdateSetsValue{}
+	hasChanges := false
+
+	// Handle string field changes
+	utils.CompareAndSetStringField(plan.Name, state.Name, func(v *string) { imageUpdateSetProps.Name = v }, &hasChanges)
+	utils.CompareAndSetStringField(plan.Type, state.Type, func(v *string) { imageUpdateSetProps.Type = v }, &hasChanges)
+
+	// Handle boolean field changes
+	utils.CompareAndSetBoolField(plan.Enable, state.Enable, func(v *bool) { imageUpdateSetProps.Enable = v }, &hasChanges)
+	utils.CompareAndSetBoolField(plan.UpgraderOnSummary, state.UpgraderOnSummary, func(v *bool) { imageUpdateSetProps.UpgraderOnSummary = v }, &hasChanges)
+	utils.CompareAndSetBoolField(plan.InstallationOnSummary, state.InstallationOnSummary, func(v *bool) { imageUpdateSetProps.InstallationOnSummary = v }, &hasChanges)
+	utils.CompareAndSetBoolField(plan.CommOnSummary, state.CommOnSummary, func(v *bool) { imageUpdateSetProps.CommOnSummary = v }, &hasChanges)
+	utils.CompareAndSetBoolField(plan.ProvisioningOnSummary, state.ProvisioningOnSummary, func(v *bool) { imageUpdateSetProps.ProvisioningOnSummary = v }, &hasChanges)
+
+	// Handle sections - the section lists have no index, so a changed list is sent in full
+	if imageUpdateSetListChanged(plan.Section, state.Section) {
+		imageUpdateSetProps.Section = buildImageUpdateSetSections(plan.Section)
+		hasChanges = true
+	}
+	if imageUpdateSetListChanged(plan.SectionElse, state.SectionElse) {
+		imageUpdateSetProps.SectionElse = buildImageUpdateSetSectionsElse(plan.SectionElse)
+		hasChanges = true
+	}
+	if imageUpdateSetListChanged(plan.SectionPointless, state.SectionPointless) {
+		imageUpdateSetProps.SectionPointless = buildImageUpdateSetSectionsPointless(plan.SectionPointless)
+		hasChanges = true
+	}
+
+	// Handle object properties
+	if len(plan.ObjectProperties) > 0 && len(state.ObjectProperties) > 0 {
+		objProps := openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueObjectProperties{}
+		op := plan.ObjectProperties[0]
+		st := state.ObjectProperties[0]
+		objPropsChanged := false
+
+		utils.CompareAndSetObjectPropertiesFields([]utils.ObjectPropertiesFieldWithComparison{
+			{Name: "FirmwareCount", PlanValue: op.FirmwareCount, StateValue: st.FirmwareCount, APIValue: &objProps.FirmwareCount},
+		}, &objPropsChanged)
+
+		if objPropsChanged {
+			imageUpdateSetProps.ObjectProperties = &objProps
+			hasChanges = true
+		}
+	}
+
+	if !hasChanges {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	success := bulkops.ExecuteResourceOperation(ctx, r.bulkOpsMgr, r.notifyOperationAdded, "update", "image_update_set", name, imageUpdateSetProps, &resp.Diagnostics)
+	if !success {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Image Update Set %s update operation completed successfully", name))
+	clearCache(ctx, r.provCtx, "image_update_sets")
+
+	var minState verityImageUpdateSetResourceModel
+	minState.Name = types.StringValue(name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &minState)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to use cached response from bulk operation to populate state with API values
+	if bulkMgr := r.provCtx.bulkOpsMgr; bulkMgr != nil {
+		if imageUpdateSetData, exists := bulkMgr.GetResourceResponse("image_update_set", name); exists {
+			newState := populateImageUpdateSetState(ctx, minState, imageUpdateSetData, r.provCtx.mode)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+			return
+		}
+	}
+
+	// If no cached data, fall back to normal Read
+	readReq := resource.ReadRequest{
+		State: resp.State,
+	}
+	readResp := resource.ReadResponse{
+		State:       resp.State,
+		Diagnostics: resp.Diagnostics,
+	}
+
+	r.Read(ctx, readReq, &readResp)
+}
+
+func (r *verityImageUpdateSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddError(
+		"Delete Not Supported",
+		"Image Update Set resources cannot be deleted. They represent existing image update sets that can only be read and updated.",
+	)
+}
+
+func (r *verityImageUpdateSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func buildImageUpdateSetSections(items []verityImageUpdateSetSectionModel) []openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionInner {
+	sections := make([]openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionInner, 0, len(items))
+	for _, item := range items {
+		section := openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionInner{}
+
+		// Handle string fields
+		utils.SetStringFields([]utils.StringFieldMapping{
+			{FieldName: "EndpointSetNumName", APIField: &section.EndpointSetNumName, TFValue: item.EndpointSetNumName},
+			{FieldName: "EndpointSetNumTargetUpgradeVersion", APIField: &section.EndpointSetNumTargetUpgradeVersion, TFValue: item.EndpointSetNumTargetUpgradeVersion},
+			{FieldName: "EndpointSetNumTargetUpgradeVersionTime", APIField: &section.EndpointSetNumTargetUpgradeVersionTime, TFValue: item.EndpointSetNumTargetUpgradeVersionTime},
+			{FieldName: "EndpointSetNumSubrule1Type", APIField: &section.EndpointSetNumSubrule1Type, TFValue: item.EndpointSetNumSubrule1Type},
+			{FieldName: "EndpointSetNumSubrule1Value", APIField: &section.EndpointSetNumSubrule1Value, TFValue: item.EndpointSetNumSubrule1Value},
+			{FieldName: "EndpointSetNumSubrule1ReferencePath", APIField: &section.EndpointSetNumSubrule1ReferencePath, TFValue: item.EndpointSetNumSubrule1ReferencePath},
+			{FieldName: "EndpointSetNumSubrule1ReferencePathRefType", APIField: &section.EndpointSetNumSubrule1ReferencePathRefType, TFValue: item.EndpointSetNumSubrule1ReferencePathRefType},
+			{FieldName: "EndpointSetNumSubrule2Type", APIField: &section.EndpointSetNumSubrule2Type, TFValue: item.EndpointSetNumSubrule2Type},
+			{FieldName: "EndpointSetNumSubrule2Value", APIField: &section.EndpointSetNumSubrule2Value, TFValue: item.EndpointSetNumSubrule2Value},
+			{FieldName: "EndpointSetNumSubrule2ReferencePath", APIField: &section.EndpointSetNumSubrule2ReferencePath, TFValue: item.EndpointSetNumSubrule2ReferencePath},
+			{FieldName: "EndpointSetNumSubrule2ReferencePathRefType", APIField: &section.EndpointSetNumSubrule2ReferencePathRefType, TFValue: item.EndpointSetNumSubrule2ReferencePathRefType},
+			{FieldName: "EndpointSetNumSubrule3Type", APIField: &section.EndpointSetNumSubrule3Type, TFValue: item.EndpointSetNumSubrule3Type},
+			{FieldName: "EndpointSetNumSubrule3Value", APIField: &section.EndpointSetNumSubrule3Value, TFValue: item.EndpointSetNumSubrule3Value},
+			{FieldName: "EndpointSetNumSubrule3ReferencePath", APIField: &section.EndpointSetNumSubrule3ReferencePath, TFValue: item.EndpointSetNumSubrule3ReferencePath},
+			{FieldName: "EndpointSetNumSubrule3ReferencePathRefType", APIField: &section.EndpointSetNumSubrule3ReferencePathRefType, TFValue: item.EndpointSetNumSubrule3ReferencePathRefType},
+		})
+
+		// Handle boolean fields
+		utils.SetBoolFields([]utils.BoolFieldMapping{
+			{FieldName: "EndpointSetNumOnSummary", APIField: &section.EndpointSetNumOnSummary, TFValue: item.EndpointSetNumOnSummary},
+			{FieldName: "EndpointSetNumSubrule1Inverted", APIField: &section.EndpointSetNumSubrule1Inverted, TFValue: item.EndpointSetNumSubrule1Inverted},
+			{FieldName: "EndpointSetNumSubrule2Inverted", APIField: &section.EndpointSetNumSubrule2Inverted, TFValue: item.EndpointSetNumSubrule2Inverted},
+			{FieldName: "EndpointSetNumSubrule3Inverted", APIField: &section.EndpointSetNumSubrule3Inverted, TFValue: item.EndpointSetNumSubrule3Inverted},
+		})
+
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func buildImageUpdateSetSectionsElse(items []verityImageUpdateSetSectionElseModel) []openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionElseInner {
+	sections := make([]openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionElseInner, 0, len(items))
+	for _, item := range items {
+		section := openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionElseInner{}
+
+		// Handle string fields
+		utils.SetStringFields([]utils.StringFieldMapping{
+			{FieldName: "EndpointSetNumName", APIField: &section.EndpointSetNumName, TFValue: item.EndpointSetNumName},
+			{FieldName: "EndpointSetForAllOthersTargetUpgradeVersion", APIField: &section.EndpointSetForAllOthersTargetUpgradeVersion, TFValue: item.EndpointSetForAllOthersTargetUpgradeVersion},
+			{FieldName: "EndpointSetForAllOthersTargetUpgradeVersionTime", APIField: &section.EndpointSetForAllOthersTargetUpgradeVersionTime, TFValue: item.EndpointSetForAllOthersTargetUpgradeVersionTime},
+		})
+
+		// Handle boolean fields
+		utils.SetBoolFields([]utils.BoolFieldMapping{
+			{FieldName: "EndpointSetNumOnSummary", APIField: &section.EndpointSetNumOnSummary, TFValue: item.EndpointSetNumOnSummary},
+		})
+
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func buildImageUpdateSetSectionsPointless(items []verityImageUpdateSetSectionPointlessModel) []openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionPointlessInner {
+	sections := make([]openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionPointlessInner, 0, len(items))
+	for _, item := range items {
+		section := openapi.ImageupdatesetsPatchRequestImageUpdateSetsValueSectionPointlessInner{}
+
+		// Handle string fields
+		utils.SetStringFields([]utils.StringFieldMapping{
+			{FieldName: "EndpointSetNumName", APIField: &section.EndpointSetNumName, TFValue: item.EndpointSetNumName},
+			{FieldName: "EndpointSetForEndpointlessTargetUpgradeVersion", APIField: &section.EndpointSetForEndpointlessTargetUpgradeVersion, TFValue: item.EndpointSetForEndpointlessTargetUpgradeVersion},
+			{FieldName: "EndpointSetForEndpointlessTargetUpgradeVersionTime", APIField: &section.EndpointSetForEndpointlessTargetUpgradeVersionTime, TFValue: item.EndpointSetForEndpointlessTargetUpgradeVersionTime},
+		})
+
+		// Handle boolean fields
+		utils.SetBoolFields([]utils.BoolFieldMapping{
+			{FieldName: "EndpointSetNumOnSummary", APIField: &section.EndpointSetNumOnSummary, TFValue: item.EndpointSetNumOnSummary},
+		})
+
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// imageUpdateSetListChanged reports whether a section list differs between plan and state.
+func imageUpdateSetListChanged[T comparable](plan, state []T) bool {
+	if len(plan) != len(state) {
+		return true
+	}
+	for i := range plan {
+		if plan[i] != state[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func populateImageUpdateSetState(ctx context.Context, state verityImageUpdateSetResourceModel, data map[string]interface{}, mode string) verityImageUpdateSetResourceModel {
+	const resourceType = imageUpdateSetResourceType
+
+	state.Name = utils.MapStringFromAPI(data["name"])
+
+	// Boolean fields
+	state.Enable = utils.MapBoolWithMode(data, "enable", resourceType, mode)
+	state.UpgraderOnSummary = utils.MapBoolWithMode(data, "upgrader_on_summary", resourceType, mode)
+	state.InstallationOnSummary = utils.MapBoolWithMode(data, "installation_on_summary", resourceType, mode)
+	state.CommOnSummary = utils.MapBoolWithMode(data, "comm_on_summary", resourceType, mode)
+	state.ProvisioningOnSummary = utils.MapBoolWithMode(data, "provisioning_on_summary", resourceType, mode)
+
+	// String fields
+	state.Type = utils.MapStringWithMode(data, "type", resourceType, mode)
+
+	// Handle section block
+	state.Section = nil
+	if utils.FieldAppliesToMode(resourceType, "section", mode) {
+		if sectionData, ok := data["section"].([]interface{}); ok && len(sectionData) > 0 {
+			var sections []verityImageUpdateSetSectionModel
+			for _, s := range sectionData {
+				section, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				sections = append(sections, verityImageUpdateSetSectionModel{
+					EndpointSetNumName:                         utils.MapStringWithModeNested(section, "endpoint_set_num_name", resourceType, "section.endpoint_set_num_name", mode),
+					EndpointSetNumTargetUpgradeVersion:         utils.MapStringWithModeNested(section, "endpoint_set_num_target_upgrade_version", resourceType, "section.endpoint_set_num_target_upgrade_version", mode),
+					EndpointSetNumUniqueIdentifier:             utils.MapStringWithModeNested(section, "endpoint_set_num_unique_identifier", resourceType, "section.endpoint_set_num_unique_identifier", mode),
+					EndpointSetNumOnSummary:                    utils.MapBoolWithModeNested(section, "endpoint_set_num_on_summary", resourceType, "section.endpoint_set_num_on_summary", mode),
+					EndpointSetNumTargetUpgradeVersionTime:     utils.MapStringWithModeNested(section, "endpoint_set_num_target_upgrade_version_time", resourceType, "section.endpoint_set_num_target_upgrade_version_time", mode),
+					EndpointSetNumSubrule1Inverted:             utils.MapBoolWithModeNested(section, "endpoint_set_num_subrule_1_inverted", resourceType, "section.endpoint_set_num_subrule_1_inverted", mode),
+					EndpointSetNumSubrule1Type:                 utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_1_type", resourceType, "section.endpoint_set_num_subrule_1_type", mode),
+					EndpointSetNumSubrule1Value:                utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_1_value", resourceType, "section.endpoint_set_num_subrule_1_value", mode),
+					EndpointSetNumSubrule1ReferencePath:        utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_1_reference_path", resourceType, "section.endpoint_set_num_subrule_1_reference_path", mode),
+					EndpointSetNumSubrule1ReferencePathRefType: utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_1_reference_path_ref_type_", resourceType, "section.endpoint_set_num_subrule_1_reference_path_ref_type_", mode),
+					EndpointSetNumSubrule2Inverted:             utils.MapBoolWithModeNested(section, "endpoint_set_num_subrule_2_inverted", resourceType, "section.endpoint_set_num_subrule_2_inverted", mode),
+					EndpointSetNumSubrule2Type:                 utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_2_type", resourceType, "section.endpoint_set_num_subrule_2_type", mode),
+					EndpointSetNumSubrule2Value:                utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_2_value", resourceType, "section.endpoint_set_num_subrule_2_value", mode),
+					EndpointSetNumSubrule2ReferencePath:        utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_2_reference_path", resourceType, "section.endpoint_set_num_subrule_2_reference_path", mode),
+					EndpointSetNumSubrule2ReferencePathRefType: utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_2_reference_path_ref_type_", resourceType, "section.endpoint_set_num_subrule_2_reference_path_ref_type_", mode),
+					EndpointSetNumSubrule3Inverted:             utils.MapBoolWithModeNested(section, "endpoint_set_num_subrule_3_inverted", resourceType, "section.endpoint_set_num_subrule_3_inverted", mode),
+					EndpointSetNumSubrule3Type:                 utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_3_type", resourceType, "section.endpoint_set_num_subrule_3_type", mode),
+					EndpointSetNumSubrule3Value:                utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_3_value", resourceType, "section.endpoint_set_num_subrule_3_value", mode),
+					EndpointSetNumSubrule3ReferencePath:        utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_3_reference_path", resourceType, "section.endpoint_set_num_subrule_3_reference_path", mode),
+					EndpointSetNumSubrule3ReferencePathRefType: utils.MapStringWithModeNested(section, "endpoint_set_num_subrule_3_reference_path_ref_type_", resourceType, "section.endpoint_set_num_subrule_3_reference_path_ref_type_", mode),
+				})
+			}
+			state.Section = sections
+		}
+	}
+
+	// Handle section_else block
+	state.SectionElse = nil
+	if utils.FieldAppliesToMode(resourceType, "section_else", mode) {
+		if sectionData, ok := data["section_else"].([]interface{}); ok && len(sectionData) > 0 {
+			var sections []verityImageUpdateSetSectionElseModel
+			for _, s := range sectionData {
+				section, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				sections = append(sections, verityImageUpdateSetSectionElseModel{
+					EndpointSetNumName:                              utils.MapStringWithModeNested(section, "endpoint_set_num_name", resourceType, "section_else.endpoint_set_num_name", mode),
+					EndpointSetForAllOthersTargetUpgradeVersion:     utils.MapStringWithModeNested(section, "endpoint_set_for_all_others_target_upgrade_version", resourceType, "section_else.endpoint_set_for_all_others_target_upgrade_version", mode),
+					EndpointSetForAllOthersUniqueIdentifier:         utils.MapStringWithModeNested(section, "endpoint_set_for_all_others_unique_identifier", resourceType, "section_else.endpoint_set_for_all_others_unique_identifier", mode),
+					EndpointSetNumOnSummary:                         utils.MapBoolWithModeNested(section, "endpoint_set_num_on_summary", resourceType, "section_else.endpoint_set_num_on_summary", mode),
+					EndpointSetForAllOthersTargetUpgradeVersionTime: utils.MapStringWithModeNested(section, "endpoint_set_for_all_others_target_upgrade_version_time", resourceType, "section_else.endpoint_set_for_all_others_target_upgrade_version_time", mode),
+				})
+			}
+			state.SectionElse = sections
+		}
+	}
+
+	// Handle section_pointless block
+	state.SectionPointless = nil
+	if utils.FieldAppliesToMode(resourceType, "section_pointless", mode) {
+		if sectionData, ok := data["section_pointless"].([]interface{}); ok && len(sectionData) > 0 {
+			var sections []verityImageUpdateSetSectionPointlessModel
+			for _, s := range sectionData {
+				section, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				sections = append(sections, verityImageUpdateSetSectionPointlessModel{
+					EndpointSetNumName: utils.MapStringWithModeNested(section, "endpoint_set_num_name", resourceType, "section_pointless.endpoint_set_num_name", mode),
+					EndpointSetForEndpointlessTargetUpgradeVersion:     utils.MapStringWithModeNested(section, "endpoint_set_for_endpointless_target_upgrade_version", resourceType, "section_pointless.endpoint_set_for_endpointless_target_upgrade_version", mode),
+					EndpointSetForEndpointlessUniqueIdentifier:         utils.MapStringWithModeNested(section, "endpoint_set_for_endpointless_unique_identifier", resourceType, "section_pointless.endpoint_set_for_endpointless_unique_identifier", mode),
+					EndpointSetNumOnSummary:                            utils.MapBoolWithModeNested(section, "endpoint_set_num_on_summary", resourceType, "section_pointless.endpoint_set_num_on_summary", mode),
+					EndpointSetForEndpointlessTargetUpgradeVersionTime: utils.MapStringWithModeNested(section, "endpoint_set_for_endpointless_target_upgrade_version_time", resourceType, "section_pointless.endpoint_set_for_endpointless_target_upgrade_version_time", mode),
+				})
+			}
+			state.SectionPointless = sections
+		}
+	}
+
+	// Handle object_properties block
+	if utils.FieldAppliesToMode(resourceType, "object_properties", mode) {
+		if objProps, ok := data["object_properties"].(map[string]interface{}); ok {
+			objPropsModel := verityImageUpdateSetObjectPropertiesModel{
+				FirmwareCount: utils.MapInt64WithModeNested(objProps, "firmware_count", resourceType, "object_properties.firmware_count", mode),
+			}
+			state.ObjectProperties = []verityImageUpdateSetObjectPropertiesModel{objPropsModel}
+		} else {
+			state.ObjectProperties = nil
+		}
+	} else {
+		state.ObjectProperties = nil
+	}
+
+	return state
+}
+
+func (r *verityImageUpdateSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// =========================================================================
+	// Skip if deleting
+	// =========================================================================
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan verityImageUpdateSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// =========================================================================
+	// Mode-aware field nullification
+	// Set fields that don't apply to current mode to null to prevent
+	// "known after apply" messages for irrelevant fields.
+	// =========================================================================
+	const resourceType = imageUpdateSetResourceType
+	mode := r.provCtx.mode
+
+	nullifier := &utils.ModeFieldNullifier{
+		Ctx:          ctx,
+		ResourceType: resourceType,
+		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
+		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
+	}
+
+	nullifier.NullifyStrings(
+		"type",
+	)
+
+	nullifier.NullifyBools(
+		"enable", "upgrader_on_summary", "installation_on_summary", "comm_on_summary", "provisioning_on_summary",
+	)
+
+	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
+		BlockName: "section",
+		ItemCount: len(plan.Section),
+		StringFields: []string{
+			"endpoint_set_num_name", "endpoint_set_num_target_upgrade_version", "endpoint_set_num_unique_identifier",
+			"endpoint_set_num_target_upgrade_version_time",
+			"endpoint_set_num_subrule_1_type", "endpoint_set_num_subrule_1_value",
+			"endpoint_set_num_subrule_1_reference_path", "endpoint_set_num_subrule_1_reference_path_ref_type_",
+			"endpoint_set_num_subrule_2_type", "endpoint_set_num_subrule_2_value",
+			"endpoint_set_num_subrule_2_reference_path", "endpoint_set_num_subrule_2_reference_path_ref_type_",
+			"endpoint_set_num_subrule_3_type", "endpoint_set_num_subrule_3_value",
+			"endpoint_set_num_subrule_3_reference_path", "endpoint_set_num_subrule_3_reference_path_ref_type_",
+		},
+		BoolFields: []string{
+			"endpoint_set_num_on_summary",
+			"endpoint_set_num_subrule_1_inverted", "endpoint_set_num_subrule_2_inverted", "endpoint_set_num_subrule_3_inverted",
+		},
+	})
+
+	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
+		BlockName: "section_else",
+		ItemCount: len(plan.SectionElse),
+		StringFields: []string{
+			"endpoint_set_num_name", "endpoint_set_for_all_others_target_upgrade_version",
+			"endpoint_set_for_all_others_unique_identifier", "endpoint_set_for_all_others_target_upgrade_version_time",
+		},
+		BoolFields: []string{"endpoint_set_num_on_summary"},
+	})
+
+	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
+		BlockName: "section_pointless",
+		ItemCount: len(plan.SectionPointless),
+		StringFields: []string{
+			"endpoint_set_num_name", "endpoint_set_for_endpointless_target_upgrade_version",
+			"endpoint_set_for_endpointless_unique_identifier", "endpoint_set_for_endpointless_target_upgrade_version_time",
+		},
+		BoolFields: []string{"endpoint_set_num_on_summary"},
+	})
+
+	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
+		BlockName:   "object_properties",
+		ItemCount:   len(plan.ObjectProperties),
+		Int64Fields: []string{"firmware_count"},
+	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+}
@@ -409,7 +409,9 @@ func (r *verityIpv4ListResource) ModifyPlan(ctx context.Context, req resource.Mo
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -648,7 +648,9 @@ func (r *verityIpv6PrefixListResource) ModifyPlan(ctx context.Context, req resou
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -669,6 +671,8 @@ func (r *verityIpv6PrefixListResource) ModifyPlan(ctx context.Context, req resou
 		StringFields: []string{"notes"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
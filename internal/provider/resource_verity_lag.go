@@ -559,7 +559,9 @@ func (r *verityLagResource) ModifyPlan(ctx context.Context, req resource.ModifyP
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
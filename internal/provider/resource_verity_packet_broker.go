@@ -902,7 +902,9 @@ func (r *verityPacketBrokerResource) ModifyPlan(ctx context.Context, req resourc
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -741,7 +741,9 @@ func (r *verityPacketQueueResource) ModifyPlan(ctx context.Context, req resource
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -767,6 +769,8 @@ func (r *verityPacketQueueResource) ModifyPlan(ctx context.Context, req resource
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
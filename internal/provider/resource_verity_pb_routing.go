@@ -545,7 +545,9 @@ func (r *verityPBRoutingResource) ModifyPlan(ctx context.Context, req resource.M
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -927,7 +927,9 @@ func (r *verityPBRoutingACLResource) ModifyPlan(ctx context.Context, req resourc
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -492,7 +492,9 @@ func (r *verityPodResource) ModifyPlan(ctx context.Context, req resource.ModifyP
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -509,6 +511,8 @@ func (r *verityPodResource) ModifyPlan(ctx context.Context, req resource.ModifyP
 		StringFields: []string{"notes"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -925,7 +925,9 @@ func (r *verityPortAclResource) ModifyPlan(ctx context.Context, req resource.Mod
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
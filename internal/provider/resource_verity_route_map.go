@@ -609,7 +609,9 @@ func (r *verityRouteMapResource) ModifyPlan(ctx context.Context, req resource.Mo
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -629,4 +631,6 @@ func (r *verityRouteMapResource) ModifyPlan(ctx context.Context, req resource.Mo
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"notes"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
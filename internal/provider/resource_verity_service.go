@@ -866,7 +866,9 @@ func (r *verityServiceResource) ModifyPlan(ctx context.Context, req resource.Mod
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -899,6 +901,8 @@ func (r *verityServiceResource) ModifyPlan(ctx context.Context, req resource.Mod
 		BoolFields:   []string{"on_summary", "warn_on_no_external_source"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// CREATE operation - handle auto-assigned fields
 	// =========================================================================
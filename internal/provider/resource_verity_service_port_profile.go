@@ -774,7 +774,9 @@ func (r *verityServicePortProfileResource) ModifyPlan(ctx context.Context, req r
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -808,6 +810,8 @@ func (r *verityServicePortProfileResource) ModifyPlan(ctx context.Context, req r
 		BoolFields:   []string{"on_summary"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -484,7 +484,9 @@ func (r *veritySfpBreakoutResource) ModifyPlan(ctx context.Context, req resource
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
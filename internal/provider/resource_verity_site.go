@@ -1070,7 +1070,9 @@ func (r *veritySiteResource) ModifyPlan(ctx context.Context, req resource.Modify
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1133,6 +1135,8 @@ func (r *veritySiteResource) ModifyPlan(ctx context.Context, req resource.Modify
 		},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// CREATE operation - handle auto-assigned fields
 	// =========================================================================
@@ -453,7 +453,9 @@ func (r *veritySpinePlaneResource) ModifyPlan(ctx context.Context, req resource.
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyBools(
@@ -465,4 +467,6 @@ func (r *veritySpinePlaneResource) ModifyPlan(ctx context.Context, req resource.
 		ItemCount:    len(plan.ObjectProperties),
 		StringFields: []string{"notes"},
 	})
+
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
 }
@@ -1605,7 +1605,9 @@ func (r *veritySwitchpointResource) ModifyPlan(ctx context.Context, req resource
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1665,6 +1667,8 @@ func (r *veritySwitchpointResource) ModifyPlan(ctx context.Context, req resource
 		Int64Fields:  []string{"number_of_multipoints"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// CREATE operation - handle auto-assigned fields
 	// =========================================================================
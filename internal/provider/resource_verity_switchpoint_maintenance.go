@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/openapi"
+)
+
+var (
+	_ resource.Resource                = &veritySwitchpointMaintenanceResource{}
+	_ resource.ResourceWithConfigure   = &veritySwitchpointMaintenanceResource{}
+	_ resource.ResourceWithImportState = &veritySwitchpointMaintenanceResource{}
+)
+
+func NewVeritySwitchpointMaintenanceResource() resource.Resource {
+	return &veritySwitchpointMaintenanceResource{}
+}
+
+// veritySwitchpointMaintenanceResource marks switchpoints out of service for as long as the
+// resource exists. Like the upgrade endpoint, the mark-out-of-service endpoint is called directly
+// instead of going through the bulk operations manager.
+type veritySwitchpointMaintenanceResource struct {
+	provCtx *providerContext
+	client  *openapi.APIClient
+}
+
+type veritySwitchpointMaintenanceResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	DeviceNames types.Set    `tfsdk:"device_names"`
+}
+
+func (r *veritySwitchpointMaintenanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switchpoint_maintenance"
+}
+
+func (r *veritySwitchpointMaintenanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provCtx, ok := req.ProviderData.(*providerContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.provCtx = provCtx
+	r.client = provCtx.client
+}
+
+func (r *veritySwitchpointMaintenanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Marks switchpoints out of service. The switchpoints are put back in service when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this maintenance window.",
+				Computed:    true,
+			},
+			"device_names": schema.SetAttribute{
+				Description: "Names of the switchpoints to mark out of service.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *veritySwitchpointMaintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan veritySwitchpointMaintenanceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deviceNames []string
+	resp.Diagnostics.Append(plan.DeviceNames.ElementsAs(ctx, &deviceNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.markOutOfService(ctx, deviceNames, true, &resp.Diagnostics) {
+		return
+	}
+
+	plan.Id = types.StringValue(switchpointMaintenanceId(deviceNames))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *veritySwitchpointMaintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state veritySwitchpointMaintenanceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stateNames []string
+	resp.Diagnostics.Append(state.DeviceNames.ElementsAs(ctx, &stateNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return
+	}
+
+	outOfService, err := r.fetchOutOfServiceSwitchpoints(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(
+			utils.FormatOpenAPIError(err, "Failed to Read Out of Service Switchpoints")...,
+		)
+		return
+	}
+
+	// Only keep the switchpoints that are still out of service, so switchpoints put back
+	// in service outside of Terraform show up as a change in the next plan
+	deviceNames := make([]string, 0, len(stateNames))
+	for _, name := range stateNames {
+		if outOfService[name] {
+			deviceNames = append(deviceNames, name)
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("Switchpoint %s is no longer marked out of service", name))
+		}
+	}
+	sort.Strings(deviceNames)
+
+	deviceNamesSet, setDiags := types.SetValueFrom(ctx, types.StringType, deviceNames)
+	resp.Diagnostics.Append(setDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.DeviceNames = deviceNamesSet
+	if state.Id.IsNull() || state.Id.ValueString() == "" {
+		state.Id = types.StringValue(switchpointMaintenanceId(stateNames))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *veritySwitchpointMaintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state veritySwitchpointMaintenanceResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planNames, stateNames []string
+	resp.Diagnostics.Append(plan.DeviceNames.ElementsAs(ctx, &planNames, false)...)
+	resp.Diagnostics.Append(state.DeviceNames.ElementsAs(ctx, &stateNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inPlan := make(map[string]bool, len(planNames))
+	for _, name := range planNames {
+		inPlan[name] = true
+	}
+	inState := make(map[string]bool, len(stateNames))
+	for _, name := range stateNames {
+		inState[name] = true
+	}
+
+	added := make([]string, 0)
+	for _, name := range planNames {
+		if !inState[name] {
+			added = append(added, name)
+		}
+	}
+	removed := make([]string, 0)
+	for _, name := range stateNames {
+		if !inPlan[name] {
+			removed = append(removed, name)
+		}
+	}
+
+	if len(added) > 0 {
+		if !r.markOutOfService(ctx, added, true, &resp.Diagnostics) {
+			return
+		}
+	}
+	if len(removed) > 0 {
+		if !r.markOutOfService(ctx, removed, false, &resp.Diagnostics) {
+			return
+		}
+	}
+
+	plan.Id = types.StringValue(switchpointMaintenanceId(planNames))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *veritySwitchpointMaintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state veritySwitchpointMaintenanceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deviceNames []string
+	resp.Diagnostics.Append(state.DeviceNames.ElementsAs(ctx, &deviceNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(deviceNames) > 0 {
+		if !r.markOutOfService(ctx, deviceNames, false, &resp.Diagnostics) {
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// ImportState accepts a comma separated list of switchpoint names.
+func (r *veritySwitchpointMaintenanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	deviceNames := make([]string, 0)
+	for _, name := range strings.Split(req.ID, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			deviceNames = append(deviceNames, name)
+		}
+	}
+	if len(deviceNames) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected a comma separated list of switchpoint names",
+		)
+		return
+	}
+
+	deviceNamesSet, diags := types.SetValueFrom(ctx, types.StringType, deviceNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), switchpointMaintenanceId(deviceNames))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("device_names"), deviceNamesSet)...)
+}
+
+func (r *veritySwitchpointMaintenanceResource) markOutOfService(ctx context.Context, deviceNames []string, mos bool, diagnostics *diag.Diagnostics) bool {
+	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
+		diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return false
+	}
+
+	action := "out of service"
+	if !mos {
+		action = "back in service"
+	}
+
+	sort.Strings(deviceNames)
+	tflog.Info(ctx, fmt.Sprintf("Marking switchpoints %s %s", strings.Join(deviceNames, ", "), action))
+
+	mosReq := openapi.NewSwitchpointsMarkoutofservicePutRequest(deviceNames)
+	mosReq.SetMos(mos)
+	httpResp, err := r.client.SwitchpointsAPI.SwitchpointsMarkoutofservicePut(ctx).SwitchpointsMarkoutofservicePutRequest(*mosReq).Execute()
+	if err != nil {
+		diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to Mark Switchpoints %s", action))...,
+		)
+		return false
+	}
+	if httpResp != nil && httpResp.Body != nil {
+		httpResp.Body.Close()
+	}
+
+	return true
+}
+
+// fetchOutOfServiceSwitchpoints returns the names of all switchpoints currently marked out of service.
+func (r *veritySwitchpointMaintenanceResource) fetchOutOfServiceSwitchpoints(ctx context.Context) (map[string]bool, error) {
+	httpResp, err := r.client.SwitchpointsAPI.SwitchpointsMarkoutofserviceGet(ctx).Mos(true).Execute()
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read out of service switchpoints response: %v", err)
+	}
+
+	names, err := utils.ParseOutOfServiceSwitchpoints(body)
+	if err != nil {
+		return nil, err
+	}
+
+	outOfService := make(map[string]bool, len(names))
+	for _, name := range names {
+		outOfService[name] = true
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Found %d switchpoints marked out of service", len(outOfService)))
+	return outOfService, nil
+}
+
+func switchpointMaintenanceId(deviceNames []string) string {
+	names := append([]string{}, deviceNames...)
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
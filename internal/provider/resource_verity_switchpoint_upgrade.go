@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/openapi"
+)
+
+var (
+	_ resource.Resource              = &veritySwitchpointUpgradeResource{}
+	_ resource.ResourceWithConfigure = &veritySwitchpointUpgradeResource{}
+)
+
+func NewVeritySwitchpointUpgradeResource() resource.Resource {
+	return &veritySwitchpointUpgradeResource{}
+}
+
+// veritySwitchpointUpgradeResource triggers a firmware upgrade of a set of switchpoints.
+// The upgrade endpoint is not a bulk object endpoint, so requests are sent directly
+// instead of being queued in the bulk operations manager.
+type veritySwitchpointUpgradeResource struct {
+	provCtx *providerContext
+	client  *openapi.APIClient
+}
+
+type veritySwitchpointUpgradeResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	PackageVersion types.String `tfsdk:"package_version"`
+	DeviceNames    types.Set    `tfsdk:"device_names"`
+}
+
+func (r *veritySwitchpointUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_switchpoint_upgrade"
+}
+
+func (r *veritySwitchpointUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provCtx, ok := req.ProviderData.(*providerContext)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.provCtx = provCtx
+	r.client = provCtx.client
+}
+
+func (r *veritySwitchpointUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Triggers a firmware upgrade of a set of switchpoints. Changing the package version triggers the upgrade again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this upgrade, set when it is created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"package_version": schema.StringAttribute{
+				Description: "Version to upgrade to.",
+				Required:    true,
+			},
+			"device_names": schema.SetAttribute{
+				Description: "Names of the switchpoints to upgrade.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *veritySwitchpointUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan veritySwitchpointUpgradeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var deviceNames []string
+	resp.Diagnostics.Append(plan.DeviceNames.ElementsAs(ctx, &deviceNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version := plan.PackageVersion.ValueString()
+	if !r.upgrade(ctx, version, deviceNames, &resp.Diagnostics) {
+		return
+	}
+
+	plan.Id = types.StringValue(switchpointUpgradeId(version, deviceNames))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *veritySwitchpointUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state veritySwitchpointUpgradeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API does not expose the state of an upgrade, so the recorded target version is kept as is
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *veritySwitchpointUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state veritySwitchpointUpgradeResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planNames, stateNames []string
+	resp.Diagnostics.Append(plan.DeviceNames.ElementsAs(ctx, &planNames, false)...)
+	resp.Diagnostics.Append(state.DeviceNames.ElementsAs(ctx, &stateNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version := plan.PackageVersion.ValueString()
+
+	// A new version is rolled out to every switchpoint; otherwise only switchpoints
+	// added to the set still need to be upgraded
+	deviceNames := planNames
+	if version == state.PackageVersion.ValueString() {
+		upgraded := make(map[string]bool, len(stateNames))
+		for _, name := range stateNames {
+			upgraded[name] = true
+		}
+		deviceNames = make([]string, 0)
+		for _, name := range planNames {
+			if !upgraded[name] {
+				deviceNames = append(deviceNames, name)
+			}
+		}
+	}
+
+	if len(deviceNames) > 0 {
+		if !r.upgrade(ctx, version, deviceNames, &resp.Diagnostics) {
+			return
+		}
+	}
+
+	// the id identifies the resource, not the version it currently targets
+	plan.Id = state.Id
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *veritySwitchpointUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state veritySwitchpointUpgradeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An upgrade cannot be undone; destroying the resource only removes it from state
+	tflog.Info(ctx, fmt.Sprintf("Removing switchpoint upgrade to %s from state, switchpoints keep their current firmware", state.PackageVersion.ValueString()))
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *veritySwitchpointUpgradeResource) upgrade(ctx context.Context, version string, deviceNames []string, diagnostics *diag.Diagnostics) bool {
+	if err := ensureAuthenticated(ctx, r.provCtx); err != nil {
+		diagnostics.AddError(
+			"Failed to Authenticate",
+			fmt.Sprintf("Error authenticating with API: %s", err),
+		)
+		return false
+	}
+
+	sort.Strings(deviceNames)
+	tflog.Info(ctx, fmt.Sprintf("Upgrading switchpoints %s to %s", strings.Join(deviceNames, ", "), version))
+
+	upgradeReq := openapi.NewSwitchpointsUpgradePatchRequest(version, deviceNames)
+	httpResp, err := r.client.SwitchpointsAPI.SwitchpointsUpgradePatch(ctx).SwitchpointsUpgradePatchRequest(*upgradeReq).Execute()
+	if err != nil {
+		diagnostics.Append(
+			utils.FormatOpenAPIError(err, fmt.Sprintf("Failed to Upgrade Switchpoints to %s", version))...,
+		)
+		return false
+	}
+	if httpResp != nil && httpResp.Body != nil {
+		httpResp.Body.Close()
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Switchpoint upgrade to %s triggered for %d switchpoints", version, len(deviceNames)))
+	return true
+}
+
+func switchpointUpgradeId(version string, deviceNames []string) string {
+	names := append([]string{}, deviceNames...)
+	sort.Strings(names)
+	return fmt.Sprintf("%s:%s", version, strings.Join(names, ","))
+}
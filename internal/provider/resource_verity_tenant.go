@@ -1035,7 +1035,9 @@ func (r *verityTenantResource) ModifyPlan(ctx context.Context, req resource.Modi
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -1062,6 +1064,8 @@ func (r *verityTenantResource) ModifyPlan(ctx context.Context, req resource.Modi
 		StringFields: []string{"group"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	nullifier.NullifyNestedBlockFields(utils.NestedBlockFieldConfig{
 		BlockName:    "route_tenants",
 		ItemCount:    len(plan.RouteTenants),
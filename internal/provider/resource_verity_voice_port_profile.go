@@ -796,7 +796,9 @@ func (r *verityVoicePortProfileResource) ModifyPlan(ctx context.Context, req res
 		Ctx:          ctx,
 		ResourceType: resourceType,
 		Mode:         mode,
+		APIVersion:   r.provCtx.apiVersion,
 		Plan:         &resp.Plan,
+		Diagnostics:  &resp.Diagnostics,
 	}
 
 	nullifier.NullifyStrings(
@@ -826,6 +828,8 @@ func (r *verityVoicePortProfileResource) ModifyPlan(ctx context.Context, req res
 		BoolFields:   []string{"format_dial_plan"},
 	})
 
+	nullifier.ApplyObjectPropertiesDefaults(&req.Config, r.provCtx.defaultObjectProperties, len(plan.ObjectProperties))
+
 	// =========================================================================
 	// Skip UPDATE-specific logic during CREATE
 	// =========================================================================
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"terraform-provider-verity/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryTransport retries API requests that failed because the API was busy or temporarily
+// unavailable. It is the only place requests are retried, so reads and bulk writes follow
+// the same policy. Like httpLogTransport it logs through the context the provider was
+// configured with, as bulk requests do not carry a logger.
+type retryTransport struct {
+	base   http.RoundTripper
+	config utils.RetryConfig
+	logCtx context.Context
+}
+
+func newRetryTransport(ctx context.Context, base http.RoundTripper, config utils.RetryConfig) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.MaxRetries <= 0 {
+		return base
+	}
+	return &retryTransport{
+		base:   base,
+		config: config,
+		logCtx: ctx,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a body without GetBody has been consumed by the first attempt and cannot be sent again
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(attemptReq)
+		if !replayable || attempt >= t.config.MaxRetries {
+			return resp, err
+		}
+
+		delay, retry := t.retryDelay(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		fields := map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"attempt":     attempt + 1,
+			"max_retries": t.config.MaxRetries,
+			"delay_ms":    delay.Milliseconds(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.Debug(t.logCtx, "API request failed with retriable error, retrying", fields)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// retryDelay decides whether a request is retried and how long to wait first. A POST or PATCH
+// that failed with a server error may have been applied, so it is only retried when the API
+// rejected it unprocessed. A Retry-After header sent by the API takes precedence over the
+// backoff, but is capped at MaxDelay.
+func (t *retryTransport) retryDelay(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		if req.Context().Err() != nil || !isIdempotent(req.Method) || !isRetriableTransportError(err) {
+			return 0, false
+		}
+		return utils.CalculateBackoff(attempt, t.config), true
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return 0, false
+	}
+
+	// the body is needed to recognize a busy API, hand the caller a fresh copy
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return 0, false
+	}
+	if isIdempotent(req.Method) {
+		if !utils.IsRetriableResponse(resp.StatusCode, body) {
+			return 0, false
+		}
+	} else if !utils.IsRejectedResponse(resp.StatusCode, body) {
+		return 0, false
+	}
+
+	if delay, ok := utils.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return min(delay, t.config.MaxDelay), true
+	}
+	return utils.CalculateBackoff(attempt, t.config), true
+}
+
+// isIdempotent reports whether a request can be sent again when it is unknown whether the API
+// applied it, after a connection failure or a server error.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetriableTransportError reports whether err is a connection failure that may succeed on
+// another attempt. Certificate errors never do.
+func isRetriableTransportError(err error) bool {
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
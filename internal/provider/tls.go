@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tlsSettings holds the TLS options of the provider after the environment fallbacks
+// have been applied.
+type tlsSettings struct {
+	caCertFile         string
+	caCertPEM          string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
+}
+
+func (s tlsSettings) isSet() bool {
+	return s.caCertFile != "" || s.caCertPEM != "" || s.clientCert != "" || s.clientKey != "" || s.insecureSkipVerify
+}
+
+// buildTLSConfig returns the TLS configuration for the API client, or nil when no TLS
+// option is set and the default transport can be used as is. Custom CA certificates are
+// added to the system pool, so publicly trusted certificates keep working.
+func buildTLSConfig(s tlsSettings) (*tls.Config, error) {
+	if !s.isSet() {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.insecureSkipVerify,
+	}
+
+	if s.caCertFile != "" || s.caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if s.caCertFile != "" {
+			pem, err := os.ReadFile(s.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_cert_file %s does not contain any PEM encoded certificate", s.caCertFile)
+			}
+		}
+
+		if s.caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(s.caCertPEM)) {
+			return nil, fmt.Errorf("ca_cert_pem does not contain any PEM encoded certificate")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if s.clientCert != "" || s.clientKey != "" {
+		if s.clientCert == "" || s.clientKey == "" {
+			return nil, fmt.Errorf("client_cert and client_key must be set together")
+		}
+
+		certPEM, err := loadPEM(s.clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_cert: %w", err)
+		}
+		keyPEM, err := loadPEM(s.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadPEM returns PEM content given either inline or as a path to a file.
+func loadPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpTransportSettings holds the connection options of the API client.
+type httpTransportSettings struct {
+	tlsConfig *tls.Config
+	// proxyURL overrides the HTTP_PROXY/HTTPS_PROXY environment variables when set
+	proxyURL     *url.URL
+	maxIdleConns int
+}
+
+// newHTTPTransport returns the base transport of the API client.
+func newHTTPTransport(s httpTransportSettings) http.RoundTripper {
+	if s.tlsConfig == nil && s.proxyURL == nil && s.maxIdleConns == 0 {
+		return http.DefaultTransport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if s.tlsConfig != nil {
+		transport.TLSClientConfig = s.tlsConfig
+	}
+	if s.proxyURL != nil {
+		transport.Proxy = http.ProxyURL(s.proxyURL)
+	}
+	if s.maxIdleConns > 0 {
+		// all requests go to a single host, so the per-host limit is the one that matters
+		transport.MaxIdleConns = s.maxIdleConns
+		transport.MaxIdleConnsPerHost = s.maxIdleConns
+	}
+	return transport
+}
+
+// parseProxyURL validates the proxy_url provider attribute.
+func parseProxyURL(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("scheme must be http, https or socks5, got: %q", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("missing host in %q", value)
+	}
+	return proxyURL, nil
+}
+
+// changesetExcludedPaths lists API paths that do not accept the changeset_name
+// query parameter. Requests to these paths always go to the live configuration.
+var changesetExcludedPaths = []string{
+	"/auth",
+	"/version",
+	"/switchpoints/currentconfig",
+	"/switchpoints/markoutofservice",
+	"/switchpoints/upgrade",
+}
+
+// changesetTransport adds the changeset_name query parameter to every API request
+// so that reads and writes are scoped to a named changeset instead of the live config.
+type changesetTransport struct {
+	base      http.RoundTripper
+	changeset string
+}
+
+// newChangesetTransport wraps base so that requests are scoped to the named changeset.
+func newChangesetTransport(base http.RoundTripper, changeset string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &changesetTransport{
+		base:      base,
+		changeset: changeset,
+	}
+}
+
+func (t *changesetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.changeset == "" || !acceptsChangeset(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+
+	query := req.URL.Query()
+	if query.Get("changeset_name") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the original request
+	scoped := req.Clone(req.Context())
+	query.Set("changeset_name", t.changeset)
+	scoped.URL.RawQuery = query.Encode()
+
+	return t.base.RoundTrip(scoped)
+}
+
+func acceptsChangeset(path string) bool {
+	for _, excluded := range changesetExcludedPaths {
+		if strings.HasSuffix(path, excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	authCookieName = "ivn_api"
+	// defaultTokenLifetime is assumed when the auth response does not say when the token expires
+	defaultTokenLifetime = 24 * time.Hour
+)
+
+// reauthTransport authenticates again when the API rejects the session token, e.g. because
+// the session timed out during a long apply, and replays the rejected request once.
+type reauthTransport struct {
+	base           http.RoundTripper
+	reauthenticate func(ctx context.Context, rejectedToken string, statusCode int) (string, error)
+}
+
+func newReauthTransport(base http.RoundTripper, reauthenticate func(ctx context.Context, rejectedToken string, statusCode int) (string, error)) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &reauthTransport{
+		base:           base,
+		reauthenticate: reauthenticate,
+	}
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !isAuthRejection(resp.StatusCode) || strings.HasSuffix(req.URL.Path, "/auth") {
+		return resp, err
+	}
+	// the body has already been sent and cannot be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, err
+	}
+
+	rejectedToken := ""
+	if cookie, cookieErr := req.Cookie(authCookieName); cookieErr == nil {
+		rejectedToken = cookie.Value
+	}
+
+	token, authErr := t.reauthenticate(req.Context(), rejectedToken, resp.StatusCode)
+	if authErr != nil {
+		tflog.Warn(req.Context(), "Failed to authenticate again after the API rejected the token", map[string]interface{}{
+			"error": authErr.Error(),
+		})
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	setAuthCookie(retry, req.Cookies(), token)
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	tflog.Debug(req.Context(), "Replaying request with new token", map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	})
+	return t.base.RoundTrip(retry)
+}
+
+func isAuthRejection(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
+
+// setAuthCookie replaces the token in the Cookie header that the client's jar added to req.
+func setAuthCookie(req *http.Request, cookies []*http.Cookie, token string) {
+	req.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if cookie.Name != authCookieName {
+			req.AddCookie(cookie)
+		}
+	}
+	req.AddCookie(&http.Cookie{Name: authCookieName, Value: token})
+}
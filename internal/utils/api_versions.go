@@ -0,0 +1,36 @@
+package utils
+
+import "fmt"
+
+// MinSupportedAPIMinor is the oldest minor API version of SupportedAPIMajor the provider
+// accepts. Newer minor versions are accepted as well: fields the provider does not know are
+// never sent, and fields removed in a later version carry an Until range in ModeFields.
+// Lower it only together with the Since ranges of fields older servers do not know.
+const MinSupportedAPIMinor = 5
+
+// FieldAppliesToAPIVersion reports whether a field exists in the API version reported by the
+// server. It returns true when the version is unknown.
+func FieldAppliesToAPIVersion(resourceType, fieldName, apiVersion string) bool {
+	if apiVersion == "" {
+		return true
+	}
+
+	major, minor, err := ParseApiVersion(apiVersion)
+	if err != nil || major != SupportedAPIMajor {
+		return true
+	}
+	return FieldAppliesToVersion(resourceType, fieldName, minor)
+}
+
+// IsNewerAPIVersion reports whether the server runs a minor API version released after the
+// one the provider schemas were generated from. Fields added in such a version are not
+// managed by the provider.
+func IsNewerAPIVersion(apiVersion string) bool {
+	major, minor, err := ParseApiVersion(apiVersion)
+	return err == nil && major == SupportedAPIMajor && minor > SupportedAPIMinor
+}
+
+// SupportedAPIVersionRange describes the accepted API versions for error messages.
+func SupportedAPIVersionRange() string {
+	return fmt.Sprintf("%d.%d or later %d.x", SupportedAPIMajor, MinSupportedAPIMinor, SupportedAPIMajor)
+}
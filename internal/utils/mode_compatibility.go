@@ -10,7 +10,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// SupportedAPIVersion defines the API version this provider is built for
+// SupportedAPIMajor and SupportedAPIMinor are the API version the provider schemas are
+// generated from. Other minor versions are accepted as described in api_versions.go.
 const (
 	SupportedAPIMajor = 6
 	SupportedAPIMinor = 5
@@ -65,6 +66,7 @@ var ResourceCompatibility = map[string]ResourceMode{
 	"verity_diagnostics_profile":      ResourceModeBoth,
 	"verity_eth_port_profile":         ResourceModeBoth,
 	"verity_eth_port_settings":        ResourceModeBoth,
+	"verity_image_update_set":         ResourceModeBoth,
 	"verity_ipv4_list":                ResourceModeBoth,
 	"verity_ipv6_list":                ResourceModeBoth,
 	"verity_lag":                      ResourceModeBoth,
@@ -76,22 +78,25 @@ var ResourceCompatibility = map[string]ResourceMode{
 	"verity_sflow_collector":          ResourceModeBoth,
 	"verity_site":                     ResourceModeBoth,
 	"verity_switchpoint":              ResourceModeBoth,
+	"verity_switchpoint_maintenance":  ResourceModeBoth,
+	"verity_switchpoint_upgrade":      ResourceModeBoth,
 	"verity_threshold_group":          ResourceModeBoth,
 	"verity_threshold":                ResourceModeBoth,
 	"verity_grouping_rule":            ResourceModeBoth,
 }
 
-// ValidateAPIVersion checks if the API version matches the supported version.
+// ValidateAPIVersion checks if the API version is one the provider supports: the supported
+// major version, from MinSupportedAPIMinor on.
 func ValidateAPIVersion(apiVersion string) error {
 	major, minor, err := ParseApiVersion(apiVersion)
 	if err != nil {
-		return fmt.Errorf("failed to parse API version '%s': %w. This Terraform provider requires API version %d.%d",
-			apiVersion, err, SupportedAPIMajor, SupportedAPIMinor)
+		return fmt.Errorf("failed to parse API version '%s': %w. This Terraform provider requires API version %s",
+			apiVersion, err, SupportedAPIVersionRange())
 	}
 
-	if major != SupportedAPIMajor || minor != SupportedAPIMinor {
-		return fmt.Errorf("API version mismatch: server is running API version %d.%d, but this Terraform provider is built for API version %d.%d. Please use a Terraform provider version that matches your API version",
-			major, minor, SupportedAPIMajor, SupportedAPIMinor)
+	if major != SupportedAPIMajor || minor < MinSupportedAPIMinor {
+		return fmt.Errorf("API version mismatch: server is running API version %d.%d, but this Terraform provider supports API version %s. Please use a Terraform provider version that matches your API version",
+			major, minor, SupportedAPIVersionRange())
 	}
 
 	return nil
@@ -2,7 +2,9 @@ package utils
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,48 +15,94 @@ import (
 // ============================================================================
 
 // ModeFieldNullifier provides a way to nullify fields that don't apply to the current mode
+// or to the API version of the server
 type ModeFieldNullifier struct {
 	Ctx          context.Context
 	ResourceType string
 	Mode         string
-	Plan         interface {
+	// APIVersion is the version reported by the server, fields outside their ModeFields
+	// version range are nullified. Empty skips the version check.
+	APIVersion string
+	Plan       interface {
+		GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
 		SetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
 	}
+	// Diagnostics receives an error for every configured field the server's API version does
+	// not know. When nil, such fields are nullified like fields of the other mode.
+	Diagnostics *diag.Diagnostics
 }
 
-// NullifyStrings sets string fields to null if they don't apply to the current mode
+// nullify sets the attribute or block at attrPath to null if fieldPath doesn't apply to the
+// current mode or API version, and reports whether it applies. A field or block the API
+// version doesn't know is rejected instead when it has a planned value, as sending it would
+// fail and dropping it would ignore the configuration.
+func (n *ModeFieldNullifier) nullify(fieldPath string, attrPath path.Path, null attr.Value) bool {
+	if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
+		n.Plan.SetAttribute(n.Ctx, attrPath, null)
+		return false
+	}
+	if FieldAppliesToAPIVersion(n.ResourceType, fieldPath, n.APIVersion) {
+		return true
+	}
+
+	if n.Diagnostics != nil {
+		var planned attr.Value
+		diags := n.Plan.GetAttribute(n.Ctx, attrPath, &planned)
+		if !diags.HasError() && isPlanned(planned) {
+			n.Diagnostics.AddAttributeError(
+				attrPath,
+				"Unsupported Attribute",
+				fmt.Sprintf("%s is not supported by API version %s of the Verity server.", fieldPath, n.APIVersion),
+			)
+			return false
+		}
+	}
+	n.Plan.SetAttribute(n.Ctx, attrPath, null)
+	return false
+}
+
+// isPlanned reports whether a planned value was set, blocks without items count as unset.
+func isPlanned(planned attr.Value) bool {
+	if planned == nil || planned.IsNull() || planned.IsUnknown() {
+		return false
+	}
+	if list, ok := planned.(types.List); ok {
+		return len(list.Elements()) > 0
+	}
+	return true
+}
+
+// blockApplies reports whether a nested block applies to the current mode and API version.
+func (n *ModeFieldNullifier) blockApplies(blockPath string) bool {
+	return FieldAppliesToMode(n.ResourceType, blockPath, n.Mode) &&
+		FieldAppliesToAPIVersion(n.ResourceType, blockPath, n.APIVersion)
+}
+
+// NullifyStrings sets string fields to null if they don't apply to the current mode or API version
 func (n *ModeFieldNullifier) NullifyStrings(fields ...string) {
 	for _, field := range fields {
-		if !FieldAppliesToMode(n.ResourceType, field, n.Mode) {
-			n.Plan.SetAttribute(n.Ctx, path.Root(field), types.StringNull())
-		}
+		n.nullify(field, path.Root(field), types.StringNull())
 	}
 }
 
-// NullifyBools sets bool fields to null if they don't apply to the current mode
+// NullifyBools sets bool fields to null if they don't apply to the current mode or API version
 func (n *ModeFieldNullifier) NullifyBools(fields ...string) {
 	for _, field := range fields {
-		if !FieldAppliesToMode(n.ResourceType, field, n.Mode) {
-			n.Plan.SetAttribute(n.Ctx, path.Root(field), types.BoolNull())
-		}
+		n.nullify(field, path.Root(field), types.BoolNull())
 	}
 }
 
-// NullifyInt64s sets int64 fields to null if they don't apply to the current mode
+// NullifyInt64s sets int64 fields to null if they don't apply to the current mode or API version
 func (n *ModeFieldNullifier) NullifyInt64s(fields ...string) {
 	for _, field := range fields {
-		if !FieldAppliesToMode(n.ResourceType, field, n.Mode) {
-			n.Plan.SetAttribute(n.Ctx, path.Root(field), types.Int64Null())
-		}
+		n.nullify(field, path.Root(field), types.Int64Null())
 	}
 }
 
-// NullifyNumbers sets Number fields to null if they don't apply to the current mode
+// NullifyNumbers sets Number fields to null if they don't apply to the current mode or API version
 func (n *ModeFieldNullifier) NullifyNumbers(fields ...string) {
 	for _, field := range fields {
-		if !FieldAppliesToMode(n.ResourceType, field, n.Mode) {
-			n.Plan.SetAttribute(n.Ctx, path.Root(field), types.NumberNull())
-		}
+		n.nullify(field, path.Root(field), types.NumberNull())
 	}
 }
 
@@ -79,12 +127,10 @@ type NestedBlockFieldConfig struct {
 	SubBlocks    []SubBlockFieldConfig
 }
 
-// NullifyNestedBlockFields nullifies individual fields within a nested block based on mode.
+// NullifyNestedBlockFields nullifies individual fields within a nested block based on mode and API version.
 func (n *ModeFieldNullifier) NullifyNestedBlockFields(config NestedBlockFieldConfig) {
-	// First check if the block itself applies to the mode
-	if !FieldAppliesToMode(n.ResourceType, config.BlockName, n.Mode) {
-		// Block doesn't apply, nullify the entire block
-		n.Plan.SetAttribute(n.Ctx, path.Root(config.BlockName), types.ListNull(types.ObjectType{}))
+	// First check if the block itself applies, nullifying or rejecting the entire block if not
+	if !n.nullify(config.BlockName, path.Root(config.BlockName), types.ListNull(types.ObjectType{})) {
 		return
 	}
 
@@ -95,44 +141,30 @@ func (n *ModeFieldNullifier) NullifyNestedBlockFields(config NestedBlockFieldCon
 
 		// Nullify string fields that don't apply
 		for _, field := range config.StringFields {
-			fieldPath := config.BlockName + "." + field
-			if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-				n.Plan.SetAttribute(n.Ctx, basePath.AtName(field), types.StringNull())
-			}
+			n.nullify(config.BlockName+"."+field, basePath.AtName(field), types.StringNull())
 		}
 
 		// Nullify bool fields that don't apply
 		for _, field := range config.BoolFields {
-			fieldPath := config.BlockName + "." + field
-			if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-				n.Plan.SetAttribute(n.Ctx, basePath.AtName(field), types.BoolNull())
-			}
+			n.nullify(config.BlockName+"."+field, basePath.AtName(field), types.BoolNull())
 		}
 
 		// Nullify int64 fields that don't apply
 		for _, field := range config.Int64Fields {
-			fieldPath := config.BlockName + "." + field
-			if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-				n.Plan.SetAttribute(n.Ctx, basePath.AtName(field), types.Int64Null())
-			}
+			n.nullify(config.BlockName+"."+field, basePath.AtName(field), types.Int64Null())
 		}
 
 		// Nullify number fields that don't apply
 		for _, field := range config.NumberFields {
-			fieldPath := config.BlockName + "." + field
-			if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-				n.Plan.SetAttribute(n.Ctx, basePath.AtName(field), types.NumberNull())
-			}
+			n.nullify(config.BlockName+"."+field, basePath.AtName(field), types.NumberNull())
 		}
 
 		// Handle sub-blocks (deeply nested blocks)
 		for _, subBlock := range config.SubBlocks {
 			subBlockPath := config.BlockName + "." + subBlock.SubBlockName
 
-			// Check if sub-block applies to mode
-			if !FieldAppliesToMode(n.ResourceType, subBlockPath, n.Mode) {
-				// Sub-block doesn't apply, nullify the entire sub-block
-				n.Plan.SetAttribute(n.Ctx, basePath.AtName(subBlock.SubBlockName), types.ListNull(types.ObjectType{}))
+			// Check if sub-block applies, nullifying or rejecting the entire sub-block if not
+			if !n.nullify(subBlockPath, basePath.AtName(subBlock.SubBlockName), types.ListNull(types.ObjectType{})) {
 				continue
 			}
 
@@ -148,34 +180,22 @@ func (n *ModeFieldNullifier) NullifyNestedBlockFields(config NestedBlockFieldCon
 
 				// Nullify string fields that don't apply
 				for _, field := range subBlock.StringFields {
-					fieldPath := subBlockPath + "." + field
-					if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-						n.Plan.SetAttribute(n.Ctx, subBasePath.AtName(field), types.StringNull())
-					}
+					n.nullify(subBlockPath+"."+field, subBasePath.AtName(field), types.StringNull())
 				}
 
 				// Nullify bool fields that don't apply
 				for _, field := range subBlock.BoolFields {
-					fieldPath := subBlockPath + "." + field
-					if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-						n.Plan.SetAttribute(n.Ctx, subBasePath.AtName(field), types.BoolNull())
-					}
+					n.nullify(subBlockPath+"."+field, subBasePath.AtName(field), types.BoolNull())
 				}
 
 				// Nullify int64 fields that don't apply
 				for _, field := range subBlock.Int64Fields {
-					fieldPath := subBlockPath + "." + field
-					if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-						n.Plan.SetAttribute(n.Ctx, subBasePath.AtName(field), types.Int64Null())
-					}
+					n.nullify(subBlockPath+"."+field, subBasePath.AtName(field), types.Int64Null())
 				}
 
 				// Nullify number fields that don't apply
 				for _, field := range subBlock.NumberFields {
-					fieldPath := subBlockPath + "." + field
-					if !FieldAppliesToMode(n.ResourceType, fieldPath, n.Mode) {
-						n.Plan.SetAttribute(n.Ctx, subBasePath.AtName(field), types.NumberNull())
-					}
+					n.nullify(subBlockPath+"."+field, subBasePath.AtName(field), types.NumberNull())
 				}
 			}
 		}
@@ -1,8 +1,17 @@
 package utils
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	"terraform-provider-verity/openapi"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -181,3 +190,98 @@ func MapObjectPropertiesInt64FieldsFromAPI(objPropsMap map[string]interface{}, f
 	}
 	return result
 }
+
+// ObjectPropertiesStringFields returns the string fields of a schema's object_properties
+// block, or nil when the schema has no such block.
+func ObjectPropertiesStringFields(ctx context.Context, schema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}) map[string]bool {
+	elemType, diags := schema.TypeAtPath(ctx, path.Root("object_properties").AtListIndex(0))
+	if diags.HasError() {
+		return nil
+	}
+	objectType, ok := elemType.(attr.TypeWithAttributeTypes)
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	for name, attrType := range objectType.AttributeTypes() {
+		if attrType.Equal(types.StringType) {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// ApplyObjectPropertiesDefaults fills string fields of the object_properties block that are
+// not set in the configuration with the provider's default_object_properties, so the plan
+// shows the values that are sent on create and update. Fields the resource does not have or
+// that don't apply to the current mode or API version are skipped.
+//
+// Terraform rejects a plan whose number of blocks differs from the configuration, so a
+// missing block can't be added here. Instead a warning names the defaults that were skipped.
+func (n *ModeFieldNullifier) ApplyObjectPropertiesDefaults(config interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}, defaults map[string]string, itemCount int) {
+	if len(defaults) == 0 || !n.blockApplies("object_properties") {
+		return
+	}
+
+	fields := make([]string, 0, len(defaults))
+	for field := range defaults {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	if itemCount == 0 {
+		n.warnObjectPropertiesDefaultsSkipped(fields)
+		return
+	}
+
+	for i := 0; i < itemCount; i++ {
+		basePath := path.Root("object_properties").AtListIndex(i)
+		for _, field := range fields {
+			if !n.blockApplies("object_properties." + field) {
+				continue
+			}
+
+			// fields the resource does not have, or that are not strings, fail to read
+			var configured types.String
+			if diags := config.GetAttribute(n.Ctx, basePath.AtName(field), &configured); diags.HasError() {
+				continue
+			}
+			if !configured.IsNull() {
+				continue
+			}
+			n.Plan.SetAttribute(n.Ctx, basePath.AtName(field), types.StringValue(defaults[field]))
+		}
+	}
+}
+
+// warnObjectPropertiesDefaultsSkipped warns that the defaults for fields the resource has
+// were not applied because its configuration has no object_properties block.
+func (n *ModeFieldNullifier) warnObjectPropertiesDefaultsSkipped(fields []string) {
+	plan, ok := n.Plan.(*tfsdk.Plan)
+	if !ok || n.Diagnostics == nil {
+		return
+	}
+	known := ObjectPropertiesStringFields(n.Ctx, plan.Schema)
+
+	var skipped []string
+	for _, field := range fields {
+		if known[field] && n.blockApplies("object_properties."+field) {
+			skipped = append(skipped, field)
+		}
+	}
+	if len(skipped) == 0 {
+		return
+	}
+
+	n.Diagnostics.AddAttributeWarning(
+		path.Root("object_properties"),
+		"Default Object Properties Not Applied",
+		fmt.Sprintf("The configuration has no object_properties block, so the provider's default_object_properties "+
+			"for %s are not applied. Add an empty object_properties {} block to apply them.", strings.Join(skipped, ", ")),
+	)
+}
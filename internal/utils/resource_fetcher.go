@@ -3,12 +3,12 @@ package utils
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// FetchResourceWithRetry implements the universal 3-retry pattern for fetching resources with caching
+// FetchResourceWithRetry fetches resources through the response cache. Failed requests are
+// already retried by the API client's transport, so an error returned here is final.
 func FetchResourceWithRetry[T any](
 	ctx context.Context,
 	provCtx interface{},
@@ -17,32 +17,20 @@ func FetchResourceWithRetry[T any](
 	getCachedResponseFunc func(context.Context, interface{}, string, func() (interface{}, error), ...bool) (interface{}, error),
 ) (T, error) {
 	var result T
-	var err error
-	maxRetries := 3
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			sleepTime := time.Duration(100*(attempt+1)) * time.Millisecond
-			tflog.Debug(ctx, fmt.Sprintf("Failed to fetch %s on attempt %d, retrying in %v", resourceName, attempt, sleepTime))
-			time.Sleep(sleepTime)
-		}
-
-		data, fetchErr := getCachedResponseFunc(ctx, provCtx, cacheKey, func() (interface{}, error) {
-			return fetchFunc()
-		})
-
-		if fetchErr == nil {
-			if typedResult, ok := data.(T); ok {
-				result = typedResult
-				break
-			} else {
-				err = fmt.Errorf("failed to cast result to expected type")
-			}
-		}
-		err = fetchErr
+	data, err := getCachedResponseFunc(ctx, provCtx, cacheKey, func() (interface{}, error) {
+		return fetchFunc()
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Failed to fetch %s: %v", resourceName, err))
+		return result, err
 	}
 
-	return result, err
+	typedResult, ok := data.(T)
+	if !ok {
+		return result, fmt.Errorf("failed to cast result to expected type")
+	}
+	return typedResult, nil
 }
 
 // FindResourceByAPIName searches for a resource by its API name only (not by resource ID)
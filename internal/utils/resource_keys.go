@@ -35,6 +35,7 @@ var ResourceJSONKeys = map[string]string{
 	"route_map":                "route_map",
 	"sfp_breakout":             "sfp_breakouts",
 	"site":                     "site",
+	"image_update_set":         "image_update_sets",
 	"pod":                      "pod",
 	"spine_plane":              "spine_plane",
 	"pb_routing_acl":           "pb_routing_acl",
@@ -100,6 +101,7 @@ var ImporterResourceMapping = map[string]string{
 	"routemaps":               "route_map",
 	"sfpbreakouts":            "sfp_breakouts",
 	"sites":                   "site",
+	"imageupdatesets":         "image_update_sets",
 	"pods":                    "pod",
 	"spineplanes":             "spine_plane",
 	"policybasedroutingacl":   "pb_routing_acl",
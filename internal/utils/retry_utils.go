@@ -3,15 +3,22 @@ package utils
 import (
 	"encoding/json"
 	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type RetryConfig struct {
+	// MaxRetries is the number of times a failed request is retried after the first attempt
 	MaxRetries    int
 	InitialDelay  time.Duration
 	MaxDelay      time.Duration
 	BackoffFactor float64
+	// Jitter randomizes each delay by up to this fraction, so requests that failed together
+	// do not all retry at the same moment
+	Jitter float64
 }
 
 func DefaultRetryConfig() RetryConfig {
@@ -20,36 +27,68 @@ func DefaultRetryConfig() RetryConfig {
 		InitialDelay:  500 * time.Millisecond,
 		MaxDelay:      30 * time.Second,
 		BackoffFactor: 2.0,
+		Jitter:        0.2,
 	}
 }
 
-func IsRetriableError(err error) bool {
-	if err == nil {
-		return false
-	}
+// IsRetriableResponse reports whether a failed API response is worth retrying: the API is
+// busy applying another change, rate limited, or failed with a server error.
+func IsRetriableResponse(statusCode int, body []byte) bool {
+	return IsRejectedResponse(statusCode, body) || statusCode >= http.StatusInternalServerError
+}
 
-	if openAPIErr, ok := err.(interface{ Body() []byte }); ok {
-		body := openAPIErr.Body()
-		var respBody map[string]interface{}
-		if jsonErr := json.Unmarshal(body, &respBody); jsonErr == nil {
-			if payload, ok := respBody["payload"].(string); ok {
-				return strings.Contains(strings.ToLower(payload), "system is currently being modified")
-			}
-		}
-	}
+// IsRejectedResponse reports whether a failed API response shows the request was turned away
+// before being processed: the API is busy applying another change, or rate limited. Unlike a
+// server error, such a response is safe to retry for requests that are not idempotent.
+func IsRejectedResponse(statusCode int, body []byte) bool {
+	return isSystemBeingModified(body) ||
+		statusCode == http.StatusTooManyRequests || statusCode == http.StatusRequestTimeout
+}
 
-	if httpErr, ok := err.(interface{ Code() int }); ok {
-		code := httpErr.Code()
-		return code >= 500 || code == 429 || code == 408
+func isSystemBeingModified(body []byte) bool {
+	var respBody map[string]interface{}
+	if jsonErr := json.Unmarshal(body, &respBody); jsonErr == nil {
+		if payload, ok := respBody["payload"].(string); ok {
+			return strings.Contains(strings.ToLower(payload), "system is currently being modified")
+		}
 	}
-
 	return false
 }
 
 func CalculateBackoff(attempt int, config RetryConfig) time.Duration {
-	delay := config.InitialDelay * time.Duration(math.Pow(config.BackoffFactor, float64(attempt)))
-	if delay > config.MaxDelay {
-		delay = config.MaxDelay
+	// Clamp in float64 before converting, as the exponential delay of a late attempt
+	// overflows time.Duration
+	delay := float64(config.InitialDelay) * math.Pow(config.BackoffFactor, float64(attempt))
+	if delay > float64(config.MaxDelay) {
+		delay = float64(config.MaxDelay)
+	}
+	if config.Jitter > 0 {
+		delay *= 1 + config.Jitter*(2*rand.Float64()-1)
+	}
+	return min(time.Duration(delay), config.MaxDelay)
+}
+
+// ParseRetryAfter returns the delay requested by a Retry-After header, given either in
+// seconds or as an HTTP date.
+func ParseRetryAfter(header string) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
 	}
-	return delay
+	return 0, false
 }
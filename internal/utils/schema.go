@@ -5,14 +5,33 @@
 
 package utils
 
-type FieldMode string
+// FieldMode is the mode a field applies to, and the range of minor API versions of
+// SupportedAPIMajor the field exists in. A zero MinMinor or MaxMinor leaves that end of
+// the range open.
+type FieldMode struct {
+	Mode     string
+	MinMinor int
+	MaxMinor int
+}
 
-const (
-	FieldModeBoth       FieldMode = "both"
-	FieldModeDatacenter FieldMode = "datacenter"
-	FieldModeCampus     FieldMode = "campus"
+var (
+	FieldModeBoth       = FieldMode{Mode: "both"}
+	FieldModeDatacenter = FieldMode{Mode: "datacenter"}
+	FieldModeCampus     = FieldMode{Mode: "campus"}
 )
 
+// Since returns the field mode for a field added in the given minor API version.
+func (m FieldMode) Since(minor int) FieldMode {
+	m.MinMinor = minor
+	return m
+}
+
+// Until returns the field mode for a field removed after the given minor API version.
+func (m FieldMode) Until(minor int) FieldMode {
+	m.MaxMinor = minor
+	return m
+}
+
 // FieldAppliesToMode checks if a field applies to the given mode.
 // Returns true if the field should be populated for the given mode.
 // If the field is not found in ModeFields, it defaults to true (applies to both modes).
@@ -29,19 +48,30 @@ func FieldAppliesToMode(resourceType, fieldName, mode string) bool {
 		return true
 	}
 
-	switch fieldMode {
-	case FieldModeBoth:
+	switch fieldMode.Mode {
+	case FieldModeBoth.Mode:
 		return true
-	case FieldModeDatacenter:
+	case FieldModeDatacenter.Mode:
 		return mode == "datacenter"
-	case FieldModeCampus:
+	case FieldModeCampus.Mode:
 		return mode == "campus"
 	default:
 		return true
 	}
 }
 
-// ModeFields maps field names to their applicable mode
+// FieldAppliesToVersion checks if a field exists in the given minor API version.
+// If the field is not found in ModeFields, it defaults to true (exists in all versions).
+func FieldAppliesToVersion(resourceType, fieldName string, minor int) bool {
+	fieldMode, ok := ModeFields[resourceType][fieldName]
+	if !ok {
+		return true
+	}
+	return minor >= fieldMode.MinMinor && (fieldMode.MaxMinor == 0 || minor <= fieldMode.MaxMinor)
+}
+
+// ModeFields maps field names to their applicable mode and API versions. Fields that do not
+// exist in every supported version use Since or Until, e.g. FieldModeBoth.Since(6).
 var ModeFields = map[string]map[string]FieldMode{
 	"acls": {
 		"bidirectional":             FieldModeBoth,
@@ -510,6 +540,50 @@ var ModeFields = map[string]map[string]FieldMode{
 		"object_properties":              FieldModeDatacenter,
 		"object_properties.notes":        FieldModeDatacenter,
 	},
+	"imageupdatesets": {
+		"comm_on_summary":                                                             FieldModeBoth,
+		"enable":                                                                      FieldModeBoth,
+		"installation_on_summary":                                                     FieldModeBoth,
+		"name":                                                                        FieldModeBoth,
+		"object_properties":                                                           FieldModeBoth,
+		"object_properties.firmware_count":                                            FieldModeBoth,
+		"provisioning_on_summary":                                                     FieldModeBoth,
+		"section":                                                                     FieldModeBoth,
+		"section.endpoint_set_num_name":                                               FieldModeBoth,
+		"section.endpoint_set_num_on_summary":                                         FieldModeBoth,
+		"section.endpoint_set_num_subrule_1_inverted":                                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_1_reference_path":                           FieldModeBoth,
+		"section.endpoint_set_num_subrule_1_reference_path_ref_type_":                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_1_type":                                     FieldModeBoth,
+		"section.endpoint_set_num_subrule_1_value":                                    FieldModeBoth,
+		"section.endpoint_set_num_subrule_2_inverted":                                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_2_reference_path":                           FieldModeBoth,
+		"section.endpoint_set_num_subrule_2_reference_path_ref_type_":                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_2_type":                                     FieldModeBoth,
+		"section.endpoint_set_num_subrule_2_value":                                    FieldModeBoth,
+		"section.endpoint_set_num_subrule_3_inverted":                                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_3_reference_path":                           FieldModeBoth,
+		"section.endpoint_set_num_subrule_3_reference_path_ref_type_":                 FieldModeBoth,
+		"section.endpoint_set_num_subrule_3_type":                                     FieldModeBoth,
+		"section.endpoint_set_num_subrule_3_value":                                    FieldModeBoth,
+		"section.endpoint_set_num_target_upgrade_version":                             FieldModeBoth,
+		"section.endpoint_set_num_target_upgrade_version_time":                        FieldModeBoth,
+		"section.endpoint_set_num_unique_identifier":                                  FieldModeBoth,
+		"section_else":                                                                FieldModeBoth,
+		"section_else.endpoint_set_for_all_others_target_upgrade_version":             FieldModeBoth,
+		"section_else.endpoint_set_for_all_others_target_upgrade_version_time":        FieldModeBoth,
+		"section_else.endpoint_set_for_all_others_unique_identifier":                  FieldModeBoth,
+		"section_else.endpoint_set_num_name":                                          FieldModeBoth,
+		"section_else.endpoint_set_num_on_summary":                                    FieldModeBoth,
+		"section_pointless":                                                           FieldModeBoth,
+		"section_pointless.endpoint_set_for_endpointless_target_upgrade_version":      FieldModeBoth,
+		"section_pointless.endpoint_set_for_endpointless_target_upgrade_version_time": FieldModeBoth,
+		"section_pointless.endpoint_set_for_endpointless_unique_identifier":           FieldModeBoth,
+		"section_pointless.endpoint_set_num_name":                                     FieldModeBoth,
+		"section_pointless.endpoint_set_num_on_summary":                               FieldModeBoth,
+		"type":                                                                        FieldModeBoth,
+		"upgrader_on_summary":                                                         FieldModeBoth,
+	},
 	"lags": {
 		"color":                      FieldModeBoth,
 		"enable":                     FieldModeBoth,
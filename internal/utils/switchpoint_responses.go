@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseOutOfServiceSwitchpoints extracts the sorted switchpoint names from the
+// mark-out-of-service GET response, which is either a list of names or objects, or an object
+// holding them under "device_names", "switchpoint" or "switchpoints".
+func ParseOutOfServiceSwitchpoints(body []byte) ([]string, error) {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, nil
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode out of service switchpoints response: %v", err)
+	}
+
+	var names []string
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			names = append(names, v)
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		case map[string]interface{}:
+			if name, ok := v["name"].(string); ok {
+				names = append(names, name)
+				return
+			}
+			for _, key := range []string{"device_names", "switchpoint", "switchpoints"} {
+				if nested, ok := v[key]; ok {
+					if byName, ok := nested.(map[string]interface{}); ok {
+						// Objects keyed by switchpoint name
+						for name := range byName {
+							names = append(names, name)
+						}
+					} else {
+						collect(nested)
+					}
+					return
+				}
+			}
+		}
+	}
+	collect(raw)
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// ParseSwitchpointCurrentConfig extracts the rendered configuration of one switchpoint from the
+// currentconfig response and splits it into sections. The response is either the configuration
+// text itself, a JSON string, or a JSON object holding the configuration of the switchpoint
+// (possibly keyed by its name or wrapped in an envelope key). Structured configurations are split by their top-level keys,
+// text configurations by their unindented lines.
+func ParseSwitchpointCurrentConfig(name string, body []byte) (string, map[string]string) {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		config := string(body)
+		return config, SplitConfigSections(config)
+	}
+
+	value := raw
+	for {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		if nested, ok := obj[name]; ok {
+			value = nested
+			continue
+		}
+		if nested, ok := obj["config"]; ok {
+			value = nested
+			continue
+		}
+		// Unwrap envelopes such as {"switchpoint": {...}}, but not a structured configuration
+		// that happens to have a single section
+		if nested, ok := currentConfigEnvelope(obj); ok {
+			value = nested
+			continue
+		}
+		break
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, SplitConfigSections(v)
+	case map[string]interface{}:
+		sections := make(map[string]string, len(v))
+		for key, section := range v {
+			if text, ok := section.(string); ok {
+				sections[key] = text
+				continue
+			}
+			encoded, err := json.MarshalIndent(section, "", "  ")
+			if err != nil {
+				continue
+			}
+			sections[key] = string(encoded)
+		}
+		encoded, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return string(body), sections
+		}
+		return string(encoded), sections
+	default:
+		return string(body), map[string]string{}
+	}
+}
+
+// SplitConfigSections splits a text configuration into blocks that start at an unindented line
+// and include every indented line that follows it. Comment and separator lines ("!" and "#")
+// are skipped, and repeated headers are merged into one section.
+func SplitConfigSections(config string) map[string]string {
+	blocks := make(map[string][]string)
+
+	current := ""
+	for _, line := range strings.Split(strings.ReplaceAll(config, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "!") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if line[0] != ' ' && line[0] != '\t' {
+			current = trimmed
+			blocks[current] = append(blocks[current], line)
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		blocks[current] = append(blocks[current], line)
+	}
+
+	sections := make(map[string]string, len(blocks))
+	for header, lines := range blocks {
+		sections[header] = strings.Join(lines, "\n")
+	}
+	return sections
+}
+
+// currentConfigEnvelopeKeys are the keys the currentconfig response may wrap the configuration in.
+var currentConfigEnvelopeKeys = []string{"switchpoint", "switchpoints", "current_config", "currentconfig"}
+
+// currentConfigEnvelope returns the value of an object that only holds an envelope key.
+func currentConfigEnvelope(obj map[string]interface{}) (interface{}, bool) {
+	if len(obj) != 1 {
+		return nil, false
+	}
+	for _, key := range currentConfigEnvelopeKeys {
+		if nested, ok := obj[key]; ok {
+			return nested, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-verity/openapi"
+)
+
+// WriteOnlyAttrSuffix and WriteOnlyVersionAttrSuffix name the companions of a credential
+// attribute, e.g. password_wo and password_wo_version for password.
+const (
+	WriteOnlyAttrSuffix        = "_wo"
+	WriteOnlyVersionAttrSuffix = "_wo_version"
+)
+
+// WriteOnlyStringField describes a credential attribute that can also be set through its
+// write-only companion.
+type WriteOnlyStringField struct {
+	AttrName string
+	// Version is the <attr>_wo_version value. It is required with <attr>_wo, so a set version
+	// means the credential is managed through the write-only companion.
+	Version types.Int64
+}
+
+// ValidateWriteOnlyFields reports an error for every attribute that is configured together
+// with its write-only companion, and for every write-only companion configured without its version.
+func ValidateWriteOnlyFields(ctx context.Context, config tfsdk.Config, attrNames []string, diags *diag.Diagnostics) {
+	for _, attrName := range attrNames {
+		var value, writeOnlyValue types.String
+		var version types.Int64
+		diags.Append(config.GetAttribute(ctx, path.Root(attrName), &value)...)
+		diags.Append(config.GetAttribute(ctx, path.Root(attrName+WriteOnlyAttrSuffix), &writeOnlyValue)...)
+		diags.Append(config.GetAttribute(ctx, path.Root(attrName+WriteOnlyVersionAttrSuffix), &version)...)
+		if diags.HasError() {
+			return
+		}
+
+		if !value.IsNull() && !writeOnlyValue.IsNull() {
+			diags.AddAttributeError(
+				path.Root(attrName+WriteOnlyAttrSuffix),
+				"Conflicting Attributes",
+				fmt.Sprintf("Only one of %s and %s%s can be configured.", attrName, attrName, WriteOnlyAttrSuffix),
+			)
+		}
+		if !writeOnlyValue.IsNull() && version.IsNull() {
+			diags.AddAttributeError(
+				path.Root(attrName+WriteOnlyVersionAttrSuffix),
+				"Missing Attribute Configuration",
+				fmt.Sprintf("%s%s must be configured when %s%s is configured.", attrName, WriteOnlyVersionAttrSuffix, attrName, WriteOnlyAttrSuffix),
+			)
+		}
+	}
+}
+
+// SetWriteOnlyStringField sets the API field from a write-only config value, if one is configured.
+func SetWriteOnlyStringField(value types.String, setter func(*string)) {
+	if !value.IsNull() && !value.IsUnknown() {
+		setter(openapi.PtrString(value.ValueString()))
+	}
+}
+
+// CompareAndSetWriteOnlyStringField sets the API field from a write-only config value when its
+// version changed. Write-only values are never stored in state, so the version attribute is the
+// only way to tell that a new value should be sent.
+func CompareAndSetWriteOnlyStringField(value types.String, planVersion, stateVersion types.Int64, setter func(*string), hasChanges *bool) {
+	if value.IsNull() || value.IsUnknown() || planVersion.Equal(stateVersion) {
+		return
+	}
+	setter(openapi.PtrString(value.ValueString()))
+	*hasChanges = true
+}
+
+// NullPlanForWriteOnlyFields plans a null value for attributes that are set through their
+// write-only companion. Their value is never read back from the API, so it stays null in state.
+func NullPlanForWriteOnlyFields(ctx context.Context, plan *tfsdk.Plan, fields []WriteOnlyStringField, diags *diag.Diagnostics) {
+	for _, field := range fields {
+		if field.Version.IsNull() {
+			continue
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root(field.AttrName), types.StringNull())...)
+	}
+}
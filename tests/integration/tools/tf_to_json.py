@@ -55,6 +55,7 @@ FOLDER_TO_WRAPPER_KEY = {
     "routemaps":               "route_map",
     "sfpbreakouts":            "sfp_breakouts",
     "sites":                   "site",
+    "imageupdatesets":         "image_update_sets",
     "pods":                    "pod",
     "spineplanes":             "spine_plane",
     "policybasedroutingacl":   "pb_routing_acl",
@@ -74,7 +75,7 @@ ACL_FOLDERS = {
 }
 
 # Resources that cannot be created via PUT
-NON_CREATABLE = {"sites", "sfpbreakouts"}
+NON_CREATABLE = {"sites", "sfpbreakouts", "imageupdatesets"}
 
 # Nested blocks that should be unwrapped from a list to a single object
 SINGLE_OBJECT_BLOCKS = {"object_properties"}
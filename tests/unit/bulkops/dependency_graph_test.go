@@ -0,0 +1,224 @@
+package bulkops_test
+
+import (
+	"strings"
+	"testing"
+
+	"terraform-provider-verity/internal/bulkops"
+)
+
+func indexOf(order []string, resourceType string) int {
+	for i, rt := range order {
+		if rt == resourceType {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestResolveExecutionOrderSortsDependenciesFirst(t *testing.T) {
+	t.Parallel()
+	order, err := bulkops.ResolveExecutionOrder(map[string][]string{
+		"switchpoint":      {"bundle", "badge"},
+		"bundle":           {"eth_port_profile"},
+		"badge":            nil,
+		"eth_port_profile": {"service"},
+		"service":          nil,
+	})
+	if err != nil {
+		t.Fatalf("ResolveExecutionOrder returned error: %v", err)
+	}
+
+	expected := []string{"badge", "service", "eth_port_profile", "bundle", "switchpoint"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestResolveExecutionOrderIgnoresSelfAndUnknownDependencies(t *testing.T) {
+	t.Parallel()
+	order, err := bulkops.ResolveExecutionOrder(map[string][]string{
+		"threshold":       {"threshold"},
+		"threshold_group": {"threshold", "grouping_rule"},
+	})
+	if err != nil {
+		t.Fatalf("ResolveExecutionOrder returned error: %v", err)
+	}
+
+	expected := []string{"threshold", "threshold_group"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestResolveExecutionOrderReportsCycle(t *testing.T) {
+	t.Parallel()
+	_, err := bulkops.ResolveExecutionOrder(map[string][]string{
+		"route_map_clause": {"tenant"},
+		"route_map":        {"route_map_clause"},
+		"tenant":           {"route_map"},
+		"service":          {"tenant"},
+		"badge":            nil,
+	})
+	if err == nil {
+		t.Fatal("expected cycle error, got none")
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "route_map -> route_map_clause -> tenant -> route_map") {
+		t.Errorf("expected error to name the cycle, got %q", msg)
+	}
+	if strings.Contains(msg, "service") {
+		t.Errorf("expected error to only name types on the cycle, got %q", msg)
+	}
+}
+
+func TestExecutionOrderRespectsReferences(t *testing.T) {
+	t.Parallel()
+	// Each pair is {referenced, referencing}: the referenced type must be sent first.
+	references := map[string][][2]string{
+		"datacenter": {
+			{"ipv4_list", "pb_routing_acl"},
+			{"acl", "packet_broker"},
+			{"route_map_clause", "route_map"},
+			{"route_map", "tenant"},
+			{"tenant", "service"},
+			{"pb_routing", "service"},
+			{"gateway", "gateway_profile"},
+			{"port_acl", "eth_port_profile"},
+			{"gateway_profile", "bundle"},
+			{"bundle", "switchpoint"},
+			{"spine_plane", "switchpoint"},
+			{"switchpoint", "device_controller"},
+			{"grouping_rule", "threshold_group"},
+		},
+		"campus": {
+			{"ipv6_list", "pb_routing_acl"},
+			{"service", "service_port_profile"},
+			{"eth_port_profile", "authenticated_eth_port"},
+			{"voice_port_profile", "bundle"},
+			{"device_voice_settings", "bundle"},
+			{"lag", "site"},
+			{"threshold", "threshold_group"},
+		},
+	}
+
+	for mode, pairs := range references {
+		order, err := bulkops.ExecutionOrder(mode)
+		if err != nil {
+			t.Fatalf("ExecutionOrder(%q) returned error: %v", mode, err)
+		}
+		for _, pair := range pairs {
+			referenced, referencing := indexOf(order, pair[0]), indexOf(order, pair[1])
+			if referenced == -1 || referencing == -1 {
+				t.Errorf("%s: expected both %q and %q in order %v", mode, pair[0], pair[1], order)
+				continue
+			}
+			if referenced > referencing {
+				t.Errorf("%s: expected %q before %q, got order %v", mode, pair[0], pair[1], order)
+			}
+		}
+	}
+}
+
+func TestExecutionOrderFiltersByMode(t *testing.T) {
+	t.Parallel()
+	campus, err := bulkops.ExecutionOrder("campus")
+	if err != nil {
+		t.Fatalf("ExecutionOrder(campus) returned error: %v", err)
+	}
+	for _, rt := range []string{"tenant", "gateway", "route_map", "sfp_breakout", "packet_broker"} {
+		if indexOf(campus, rt) != -1 {
+			t.Errorf("datacenter-only type %q should not be in campus order %v", rt, campus)
+		}
+	}
+
+	datacenter, err := bulkops.ExecutionOrder("datacenter")
+	if err != nil {
+		t.Fatalf("ExecutionOrder(datacenter) returned error: %v", err)
+	}
+	for _, rt := range []string{"authenticated_eth_port", "voice_port_profile", "service_port_profile"} {
+		if indexOf(datacenter, rt) != -1 {
+			t.Errorf("campus-only type %q should not be in datacenter order %v", rt, datacenter)
+		}
+	}
+}
+
+func TestDependentAndDependencyTypes(t *testing.T) {
+	t.Parallel()
+
+	dependents := bulkops.DependentTypes("datacenter", []string{"route_map_clause"})
+	for _, rt := range []string{"route_map", "tenant", "service", "switchpoint"} {
+		if indexOf(dependents, rt) == -1 {
+			t.Errorf("expected %q to depend on route_map_clause, got %v", rt, dependents)
+		}
+	}
+	for _, rt := range []string{"route_map_clause", "community_list", "pb_routing", "badge"} {
+		if indexOf(dependents, rt) != -1 {
+			t.Errorf("%q does not depend on route_map_clause, got %v", rt, dependents)
+		}
+	}
+
+	dependencies := bulkops.DependencyTypes("datacenter", []string{"tenant"})
+	for _, rt := range []string{"route_map", "route_map_clause", "community_list"} {
+		if indexOf(dependencies, rt) == -1 {
+			t.Errorf("expected tenant to depend on %q, got %v", rt, dependencies)
+		}
+	}
+	for _, rt := range []string{"tenant", "service", "badge"} {
+		if indexOf(dependencies, rt) != -1 {
+			t.Errorf("tenant does not depend on %q, got %v", rt, dependencies)
+		}
+	}
+}
+
+func TestExecutionLevelsFollowPortProfileReferences(t *testing.T) {
+	t.Parallel()
+	// The eth port profile references of lags and bundles may point at any of these types.
+	references := map[string][][2]string{
+		"datacenter": {
+			{"gateway_profile", "lag"},
+			{"packet_broker", "lag"},
+			{"packet_broker", "bundle"},
+		},
+		"campus": {
+			{"service_port_profile", "lag"},
+			{"service_port_profile", "bundle"},
+			{"authenticated_eth_port", "bundle"},
+		},
+	}
+
+	for mode, pairs := range references {
+		levels, err := bulkops.ExecutionLevels(mode)
+		if err != nil {
+			t.Fatalf("ExecutionLevels(%q) returned error: %v", mode, err)
+		}
+		levelOf := make(map[string]int)
+		for i, level := range levels {
+			for _, rt := range level {
+				levelOf[rt] = i
+			}
+		}
+		for _, pair := range pairs {
+			referenced, ok1 := levelOf[pair[0]]
+			referencing, ok2 := levelOf[pair[1]]
+			if !ok1 || !ok2 {
+				t.Errorf("%s: expected both %q and %q in levels %v", mode, pair[0], pair[1], levels)
+				continue
+			}
+			if referenced >= referencing {
+				t.Errorf("%s: expected %q in an earlier level than %q, got levels %v", mode, pair[0], pair[1], levels)
+			}
+		}
+
+	}
+
+	for mode, profileType := range map[string]string{"datacenter": "packet_broker", "campus": "service_port_profile"} {
+		dependents := bulkops.DependentTypes(mode, []string{profileType})
+		for _, rt := range []string{"lag", "bundle", "switchpoint"} {
+			if indexOf(dependents, rt) == -1 {
+				t.Errorf("%s: expected %q to depend on %q, got %v", mode, rt, profileType, dependents)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package bulkops_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/bulkops"
+)
+
+func TestDryRunRecordsOrderedBatches(t *testing.T) {
+	t.Parallel()
+	client, requests := rollbackServer(t, func(r *http.Request) bool { return false })
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	file := filepath.Join(t.TempDir(), "requests.jsonl")
+	mgr.SetDryRun(true, file)
+
+	ctx := context.Background()
+	serviceOp := mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
+	mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+	mgr.AddDelete(ctx, "badge", "old_badge")
+
+	if diags, _ := mgr.ExecuteDatacenterOperations(ctx); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	if recorded := requests(); len(recorded) != 0 {
+		t.Errorf("expected no write requests in a dry run, got %+v", recorded)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("failed to open dry run file: %v", err)
+	}
+	defer f.Close()
+
+	var batches []bulkops.DryRunBatch
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var batch bulkops.DryRunBatch
+		if err := json.Unmarshal(scanner.Bytes(), &batch); err != nil {
+			t.Fatalf("invalid dry run line %q: %v", scanner.Text(), err)
+		}
+		batches = append(batches, batch)
+	}
+
+	var order []string
+	for _, batch := range batches {
+		order = append(order, fmt.Sprintf("%d %s %s %v", batch.Sequence, batch.Method, batch.ResourceType, batch.Names))
+	}
+	expected := []string{"1 PUT tenant [test_tenant]", "2 PUT service [test_service]", "3 DELETE badge [old_badge]"}
+	if strings.Join(order, ", ") != strings.Join(expected, ", ") {
+		t.Fatalf("expected batches %v, got %v", expected, order)
+	}
+	if !strings.Contains(string(batches[0].Body), `"tenant":{"test_tenant"`) {
+		t.Errorf("expected the tenant PUT body, got %s", batches[0].Body)
+	}
+	if batches[2].Body != nil {
+		t.Errorf("expected no body for a DELETE, got %s", batches[2].Body)
+	}
+
+	err = mgr.WaitForOperation(ctx, serviceOp, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "dry run") {
+		t.Errorf("expected the service PUT to fail as a dry run, got %v", err)
+	}
+}
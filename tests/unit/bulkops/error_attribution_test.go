@@ -0,0 +1,224 @@
+package bulkops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/bulkops"
+	"terraform-provider-verity/openapi"
+)
+
+// rejectingBadgeServer rejects badge PUTs whose body contains a badge named with the "_bad"
+// suffix, answering with the error payload built by payload from the first rejected badge
+// and the badges of the request.
+func rejectingBadgeServer(t *testing.T, payload func(rejected string, badges map[string]interface{}) interface{}) (*openapi.APIClient, func() int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	puts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/badges" && r.Method == http.MethodPut {
+			var body struct {
+				Badge map[string]interface{} `json:"badge"`
+			}
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &body)
+
+			mu.Lock()
+			puts++
+			mu.Unlock()
+
+			for name := range body.Badge {
+				if !strings.HasSuffix(name, "_bad") {
+					continue
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(payload(name, body.Badge))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"badge":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := openapi.NewConfiguration()
+	cfg.Servers = openapi.ServerConfigurations{{URL: server.URL + "/api"}}
+	cfg.HTTPClient = &http.Client{}
+
+	return openapi.NewAPIClient(cfg), func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return puts
+	}
+}
+
+// namingPayload names the rejected badge in the message, as the API does for validation errors.
+func namingPayload(rejected string, _ map[string]interface{}) interface{} {
+	return map[string]string{"payload": fmt.Sprintf("badge %q: color is not valid", rejected)}
+}
+
+// invalidRequestPayload only says that the request is invalid.
+func invalidRequestPayload(string, map[string]interface{}) interface{} {
+	return map[string]string{"payload": "invalid request"}
+}
+
+// putBadges queues a PUT for every badge, executes them as one bulk operation split into
+// batches of batchSize badges, or the default size when zero, and returns the names of the
+// badges whose operation failed.
+func putBadges(t *testing.T, client *openapi.APIClient, names []string, batchSize int) []string {
+	t.Helper()
+
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	if batchSize > 0 {
+		settings := mgr.Settings()
+		settings.MaxBatchSize = batchSize
+		mgr.SetSettings(settings)
+	}
+	ctx := context.Background()
+	opIDs := make(map[string]string, len(names))
+	for _, name := range names {
+		opIDs[name] = mgr.AddPut(ctx, "badge", name, zeroPutValue("badge"))
+	}
+
+	diags := mgr.ExecuteBulk(ctx, "badge", "PUT")
+
+	// an operation left pending would wait forever
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var failed []string
+	for _, name := range names {
+		if err := mgr.WaitForOperation(waitCtx, opIDs[name], time.Second); err != nil {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 && !diags.HasError() {
+		t.Errorf("expected an error diagnostic for the failed badges %v", failed)
+	}
+	return failed
+}
+
+func TestErrorAttribution_NamedInPayload(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, namingPayload)
+
+	names := []string{"badge_1", "badge_4_bad", "badge_2", "badge_3"}
+	failed := putBadges(t, client, names, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_4_bad]" {
+		t.Errorf("expected only badge_4_bad to fail, got %s", got)
+	}
+	// the rejected batch and the batch without the named badge
+	if got := puts(); got != 2 {
+		t.Errorf("expected 2 PUT requests, got %d", got)
+	}
+}
+
+func TestErrorAttribution_Bisect(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, invalidRequestPayload)
+
+	var names []string
+	for i := 0; i < 8; i++ {
+		names = append(names, fmt.Sprintf("badge_%d", i))
+	}
+	names[2] = "badge_2_bad"
+	names[7] = "badge_7_bad"
+
+	failed := putBadges(t, client, names, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_2_bad badge_7_bad]" {
+		t.Errorf("expected only badge_2_bad and badge_7_bad to fail, got %s", got)
+	}
+	// the batch, both halves of four, all four pairs and the four badges of the rejected pairs
+	if got := puts(); got != 11 {
+		t.Errorf("expected 11 PUT requests, got %d", got)
+	}
+}
+
+func TestErrorAttribution_SingleResourceFails(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, invalidRequestPayload)
+
+	failed := putBadges(t, client, []string{"badge_bad"}, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_bad]" {
+		t.Errorf("expected badge_bad to fail, got %s", got)
+	}
+	if got := puts(); got != 1 {
+		t.Errorf("expected 1 PUT request, got %d", got)
+	}
+}
+
+func TestErrorAttribution_EchoedRequestIsNotAttributed(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, func(_ string, badges map[string]interface{}) interface{} {
+		return map[string]interface{}{"payload": "invalid request", "badge": badges}
+	})
+
+	failed := putBadges(t, client, []string{"badge_1", "badge_2_bad", "badge_3", "badge_4"}, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_2_bad]" {
+		t.Errorf("expected only badge_2_bad to fail, got %s", got)
+	}
+	// the batch, both halves and the two badges of the rejected half
+	if got := puts(); got != 5 {
+		t.Errorf("expected 5 PUT requests, got %d", got)
+	}
+}
+
+func TestErrorAttribution_OrdinaryWordsAreNotNames(t *testing.T) {
+	t.Parallel()
+	client, _ := rejectingBadgeServer(t, func(string, map[string]interface{}) interface{} {
+		return map[string]string{"payload": "invalid value for field name"}
+	})
+
+	failed := putBadges(t, client, []string{"invalid", "value", "name", "badge_bad"}, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_bad]" {
+		t.Errorf("expected only badge_bad to fail, got %s", got)
+	}
+}
+
+func TestErrorAttribution_ErrorsKeyedByName(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, func(rejected string, _ map[string]interface{}) interface{} {
+		return map[string]interface{}{"errors": map[string]string{rejected: "color is not valid"}}
+	})
+
+	failed := putBadges(t, client, []string{"badge_1", "badge_2", "badge_3_bad"}, 0)
+
+	if got := fmt.Sprint(failed); got != "[badge_3_bad]" {
+		t.Errorf("expected only badge_3_bad to fail, got %s", got)
+	}
+	if got := puts(); got != 2 {
+		t.Errorf("expected 2 PUT requests, got %d", got)
+	}
+}
+
+func TestErrorAttribution_LaterBatchesAreSent(t *testing.T) {
+	t.Parallel()
+	client, puts := rejectingBadgeServer(t, namingPayload)
+
+	names := []string{"badge_1", "badge_2_bad", "badge_3", "badge_4"}
+	failed := putBadges(t, client, names, 2)
+
+	if got := fmt.Sprint(failed); got != "[badge_2_bad]" {
+		t.Errorf("expected only badge_2_bad to fail, got %s", got)
+	}
+	// the rejected batch and its remaining badge, in either order with the other batch
+	if got := puts(); got != 3 {
+		t.Errorf("expected 3 PUT requests, got %d", got)
+	}
+}
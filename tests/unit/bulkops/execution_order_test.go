@@ -57,120 +57,116 @@ var resourceAPIPath = map[string]string{
 	"device_controller":        "/devicecontrollers",
 	"sfp_breakout":             "/sfpbreakouts",
 	"site":                     "/sites",
+	"image_update_set":         "/imageupdatesets",
 	"service_port_profile":     "/serviceportprofiles",
 	"device_voice_settings":    "/devicevoicesettings",
 	"authenticated_eth_port":   "/authenticatedethports",
 	"voice_port_profile":       "/voiceportprofiles",
 }
 
-var dcPutOrder = []string{
-	"ipv6_prefix_list",
+// dcPatchOrder is the dependency order computed for datacenter mode: resource types are
+// grouped into dependency levels and sorted alphabetically within a level.
+var dcPatchOrder = []string{
+	"acl",
+	"as_path_access_list",
+	"badge",
 	"community_list",
-	"ipv4_prefix_list",
+	"diagnostics_port_profile",
 	"extended_community_list",
-	"as_path_access_list",
-	"route_map_clause",
-	"acl",
-	"route_map",
-	"pb_routing_acl",
-	"tenant",
-	"pb_routing",
 	"ipv4_list",
+	"ipv4_prefix_list",
 	"ipv6_list",
-	"service",
-	"port_acl",
-	"packet_broker",
-	"eth_port_profile",
+	"ipv6_prefix_list",
 	"packet_queue",
+	"pod",
+	"port_acl",
 	"sflow_collector",
+	"sfp_breakout",
+	"spine_plane",
+	"threshold",
+	"device_settings",
+	"diagnostics_profile",
+	"packet_broker",
+	"pb_routing_acl",
+	"route_map_clause",
+	"pb_routing",
+	"route_map",
+	"tenant",
 	"gateway",
-	"lag",
+	"service",
+	"eth_port_profile",
 	"eth_port_settings",
-	"diagnostics_profile",
 	"gateway_profile",
-	"device_settings",
-	"diagnostics_port_profile",
+	"lag",
 	"bundle",
-	"pod",
-	"badge",
-	"spine_plane",
 	"switchpoint",
-	"threshold",
+	"device_controller",
 	"grouping_rule",
+	"image_update_set",
+	"site",
 	"threshold_group",
-	"device_controller",
 }
 
-var campusPutOrder = []string{
+// campusPatchOrder is the dependency order computed for campus mode.
+var campusPatchOrder = []string{
+	"acl",
+	"badge",
+	"device_voice_settings",
+	"diagnostics_port_profile",
 	"ipv4_list",
 	"ipv6_list",
-	"acl",
+	"packet_queue",
+	"port_acl",
+	"sflow_collector",
+	"threshold",
+	"voice_port_profile",
+	"device_settings",
+	"diagnostics_profile",
 	"pb_routing_acl",
 	"pb_routing",
-	"port_acl",
 	"service",
 	"eth_port_profile",
-	"sflow_collector",
-	"packet_queue",
+	"eth_port_settings",
 	"service_port_profile",
-	"diagnostics_port_profile",
-	"device_voice_settings",
 	"authenticated_eth_port",
-	"diagnostics_profile",
-	"eth_port_settings",
-	"voice_port_profile",
-	"device_settings",
 	"lag",
 	"bundle",
-	"badge",
 	"switchpoint",
-	"threshold",
+	"device_controller",
 	"grouping_rule",
+	"image_update_set",
+	"site",
 	"threshold_group",
-	"device_controller",
 }
 
-var dcDeleteOrder = func() []string {
-	r := make([]string, len(dcPutOrder))
-	for i, v := range dcPutOrder {
-		r[len(dcPutOrder)-1-i] = v
-	}
-	return r
-}()
+// dcPutOrder is dcPatchOrder without "sfp_breakout", "site" and "image_update_set" (all PATCH-only resources).
+var dcPutOrder = withoutPatchOnly(dcPatchOrder)
 
-var campusDeleteOrder = func() []string {
-	r := make([]string, len(campusPutOrder))
-	for i, v := range campusPutOrder {
-		r[len(campusPutOrder)-1-i] = v
-	}
-	return r
-}()
-
-// dcPatchOrder is dcPutOrder with "sfp_breakout" prepended and "site" inserted
-// before "device_controller" (both are PATCH-only resources).
-var dcPatchOrder = func() []string {
-	result := make([]string, 0, len(dcPutOrder)+2)
-	result = append(result, "sfp_breakout")
-	for _, rt := range dcPutOrder {
-		if rt == "device_controller" {
-			result = append(result, "site")
+// campusPutOrder is campusPatchOrder without "site" and "image_update_set" (both PATCH-only resources).
+var campusPutOrder = withoutPatchOnly(campusPatchOrder)
+
+var dcDeleteOrder = reversed(dcPutOrder)
+
+var campusDeleteOrder = reversed(campusPutOrder)
+
+func withoutPatchOnly(order []string) []string {
+	result := make([]string, 0, len(order))
+	for _, rt := range order {
+		if rt == "sfp_breakout" || rt == "site" || rt == "image_update_set" {
+			continue
 		}
 		result = append(result, rt)
 	}
 	return result
-}()
-
-// campusPatchOrder is campusPutOrder with "site" inserted before "device_controller".
-var campusPatchOrder = func() []string {
-	result := make([]string, 0, len(campusPutOrder)+1)
-	for _, rt := range campusPutOrder {
-		if rt == "device_controller" {
-			result = append(result, "site")
-		}
-		result = append(result, rt)
+}
+
+func reversed(order []string) []string {
+	r := make([]string, len(order))
+	for i, v := range order {
+		r[len(order)-1-i] = v
 	}
-	return result
-}()
+	return r
+}
 
 func orderTrackingServer(t *testing.T) (*httptest.Server, *[]requestRecord, *sync.Mutex) {
 	t.Helper()
@@ -290,6 +286,8 @@ func zeroPatchValue(resourceType string) interface{} {
 		return *openapi.NewSfpbreakoutsPatchRequestSfpBreakoutsValue()
 	case "site":
 		return *openapi.NewSitesPatchRequestSiteValue()
+	case "image_update_set":
+		return *openapi.NewImageupdatesetsPatchRequestImageUpdateSetsValue()
 	default:
 		return zeroPutValue(resourceType)
 	}
@@ -547,7 +545,7 @@ func TestErrorAbortsRemainingOperations(t *testing.T) {
 	mgr.AddPut(ctx, "route_map_clause", "test_clause", zeroPutValue("route_map_clause"))
 	mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
 	// These come AFTER tenant in the DC order — should NOT execute
-	mgr.AddPut(ctx, "pb_routing", "test_pbr", zeroPutValue("pb_routing"))
+	mgr.AddPut(ctx, "gateway_profile", "test_gwp", zeroPutValue("gateway_profile"))
 	mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
 	mgr.AddPut(ctx, "gateway", "test_gw", zeroPutValue("gateway"))
 
@@ -559,7 +557,7 @@ func TestErrorAbortsRemainingOperations(t *testing.T) {
 	putPaths := filterRecords(snapshotRecords(mu, records), http.MethodPut)
 
 	for _, path := range putPaths {
-		if path == "/policybasedrouting" || path == "/services" || path == "/gateways" {
+		if path == "/gatewayprofiles" || path == "/services" || path == "/gateways" {
 			t.Errorf("resource at path %q should NOT have been called after tenant failure", path)
 		}
 	}
@@ -953,10 +951,10 @@ func TestDeleteErrorAbortsRemainingOperations(t *testing.T) {
 
 	ctx := context.Background()
 
-	// device_controller is first in reverse DC order — its failure should abort the rest
+	// device_controller is deleted before the switchpoints it references — its failure should abort the rest
 	mgr.AddDelete(ctx, "device_controller", "test_dc")
 	// These come AFTER device_controller in reverse DC order — should NOT execute
-	mgr.AddDelete(ctx, "threshold_group", "test_tg")
+	mgr.AddDelete(ctx, "switchpoint", "test_sp")
 	mgr.AddDelete(ctx, "badge", "test_badge")
 
 	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
@@ -967,7 +965,7 @@ func TestDeleteErrorAbortsRemainingOperations(t *testing.T) {
 	deletePaths := filterRecords(snapshotRecords(mu, records), http.MethodDelete)
 
 	for _, path := range deletePaths {
-		if path == "/thresholdgroups" || path == "/badges" {
+		if path == "/switchpoints" || path == "/badges" {
 			t.Errorf("path %q should NOT have been deleted after device_controller DELETE failure", path)
 		}
 	}
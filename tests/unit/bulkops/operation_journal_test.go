@@ -0,0 +1,189 @@
+package bulkops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"terraform-provider-verity/internal/bulkops"
+)
+
+// readJournal returns the entries of the journal at path.
+func readJournal(t *testing.T, path string) []bulkops.JournalEntry {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	var entries []bulkops.JournalEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry bulkops.JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid journal line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestOperationJournalRecordsIntentAndOutcome(t *testing.T) {
+	t.Parallel()
+	client, _ := rollbackServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodPut && r.URL.Path == "/services"
+	})
+	path := filepath.Join(t.TempDir(), bulkops.OperationJournalFile("https://verity.example", ""))
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	mgr.SetOperationJournal(path)
+
+	ctx := context.Background()
+	mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+	mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
+	mgr.ExecuteDatacenterOperations(ctx)
+
+	entries := readJournal(t, path)
+	if len(entries) != 4 {
+		t.Fatalf("expected an intent and an outcome per batch, got %+v", entries)
+	}
+	if entries[0].ResourceType != "tenant" || entries[0].Method != "PUT" || !reflect.DeepEqual(entries[0].Names, []string{"test_tenant"}) {
+		t.Errorf("expected the tenant PUT intent first, got %+v", entries[0])
+	}
+	if entries[1].ID != entries[0].ID || entries[1].Outcome != "succeeded" {
+		t.Errorf("expected the tenant PUT to succeed, got %+v", entries[1])
+	}
+	if entries[2].ResourceType != "service" || entries[3].ID != entries[2].ID || entries[3].Outcome != "failed" || entries[3].Error == "" {
+		t.Errorf("expected the service PUT to fail, got %+v and %+v", entries[2], entries[3])
+	}
+
+	interrupted, err := mgr.RecoverOperationJournal(ctx)
+	if err != nil || len(interrupted) != 0 {
+		t.Errorf("expected no interrupted batches, got %+v, %v", interrupted, err)
+	}
+
+	// the journal starts over with the first batch of the next run
+	mgr.AddPut(ctx, "tenant", "next_tenant", zeroPutValue("tenant"))
+	mgr.ExecuteDatacenterOperations(ctx)
+	if entries := readJournal(t, path); len(entries) != 2 || !reflect.DeepEqual(entries[0].Names, []string{"next_tenant"}) {
+		t.Errorf("expected only the batch of the next run, got %+v", entries)
+	}
+}
+
+func TestRecoverOperationJournalChecksInterruptedBatches(t *testing.T) {
+	t.Parallel()
+	client, requests := rollbackServer(t, func(r *http.Request) bool { return false })
+	path := filepath.Join(t.TempDir(), bulkops.OperationJournalFile("https://verity.example", ""))
+	journal := strings.Join([]string{
+		`{"id":"1","time":"2026-01-02T03:04:05Z","resource_type":"badge","method":"PUT","names":["b1","b2"]}`,
+		`{"id":"2","time":"2026-01-02T03:04:06Z","resource_type":"tenant","method":"PUT","names":["t1"]}`,
+		`{"id":"2","time":"2026-01-02T03:04:07Z","outcome":"succeeded"}`,
+		`{"id":"3","time":"2026-01-02T03:04:08Z","resource_type":"badge","method":"DELETE","names":["b1"]}`,
+		`{"id":"3","time":"2026-01-0`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(journal), 0o600); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	mgr.SetOperationJournal(path)
+	interrupted, err := mgr.RecoverOperationJournal(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(interrupted) != 2 {
+		t.Fatalf("expected the badge PUT and DELETE to be interrupted, got %+v", interrupted)
+	}
+	put, del := interrupted[0], interrupted[1]
+	if put.Method != "PUT" || !reflect.DeepEqual(put.Present, []string{"b1"}) || !reflect.DeepEqual(put.Absent, []string{"b2"}) {
+		t.Errorf("expected b1 to be created and b2 not, got %+v", put)
+	}
+	if del.Method != "DELETE" || !reflect.DeepEqual(del.Present, []string{"b1"}) || len(del.Absent) != 0 {
+		t.Errorf("expected b1 not to be deleted, got %+v", del)
+	}
+	if description := put.String(); !strings.Contains(description, "created: b1") || !strings.Contains(description, "not created: b2") {
+		t.Errorf("unexpected description %q", description)
+	}
+
+	if recorded := requests(); len(recorded) != 0 {
+		t.Errorf("expected recovery to only read, got %+v", recorded)
+	}
+	// a plan sends no batches, so the journal is kept for the run that does
+	if data, err := os.ReadFile(path); err != nil || string(data) != journal {
+		t.Errorf("expected recovery to leave the journal unchanged, got %q, %v", data, err)
+	}
+}
+
+func TestRecoverOperationJournalWithoutJournal(t *testing.T) {
+	t.Parallel()
+	client, _ := rollbackServer(t, func(r *http.Request) bool { return false })
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	mgr.SetOperationJournal(filepath.Join(t.TempDir(), bulkops.OperationJournalFile("https://verity.example", "")))
+
+	interrupted, err := mgr.RecoverOperationJournal(context.Background())
+	if err != nil || interrupted != nil {
+		t.Errorf("expected nothing to recover, got %+v, %v", interrupted, err)
+	}
+}
+
+func TestRecoverOperationJournalKeepsUncheckedBatches(t *testing.T) {
+	t.Parallel()
+	client, _ := rollbackServer(t, func(r *http.Request) bool { return false })
+	path := filepath.Join(t.TempDir(), bulkops.OperationJournalFile("https://verity.example", ""))
+	journal := strings.Join([]string{
+		`{"id":"1","time":"2026-01-02T03:04:05Z","resource_type":"badge","method":"PUT","names":["b1"]}`,
+		`{"id":"2","time":"2026-01-02T03:04:06Z","resource_type":"unknown_type","method":"PUT","names":["u1"]}`,
+		// the server returns no tenant data, which leaves unknown whether t1 exists
+		`{"id":"3","time":"2026-01-02T03:04:07Z","resource_type":"tenant","method":"PUT","names":["t1"]}`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(journal), 0o600); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	mgr.SetOperationJournal(path)
+	ctx := context.Background()
+	interrupted, err := mgr.RecoverOperationJournal(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(interrupted) != 3 || interrupted[0].Err != nil || interrupted[1].Err == nil || interrupted[2].Err == nil {
+		t.Fatalf("expected the badge PUT to be checked and the unknown_type and tenant PUTs not, got %+v", interrupted)
+	}
+
+	// once a batch is sent, the next run checks the batches that could not be checked again
+	mgr.AddPut(ctx, "badge", "b3", zeroPutValue("badge"))
+	mgr.ExecuteDatacenterOperations(ctx)
+	interrupted, err = mgr.RecoverOperationJournal(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(interrupted) != 2 || interrupted[0].ResourceType != "unknown_type" || !reflect.DeepEqual(interrupted[0].Names, []string{"u1"}) ||
+		interrupted[1].ResourceType != "tenant" {
+		t.Errorf("expected only the unknown_type and tenant PUTs to be kept, got %+v", interrupted)
+	}
+}
+
+func TestOperationJournalFilePerController(t *testing.T) {
+	t.Parallel()
+	names := map[string]bool{}
+	for _, name := range []string{
+		bulkops.OperationJournalFile("https://verity1.example", ""),
+		bulkops.OperationJournalFile("https://verity2.example", ""),
+		bulkops.OperationJournalFile("https://verity1.example", "cs1"),
+	} {
+		if names[name] {
+			t.Errorf("expected a journal per controller and changeset, %s is shared", name)
+		}
+		names[name] = true
+	}
+	if bulkops.OperationJournalFile("https://verity1.example", "") != bulkops.OperationJournalFile("https://verity1.example", "") {
+		t.Error("expected the journal of a controller to keep its name across runs")
+	}
+}
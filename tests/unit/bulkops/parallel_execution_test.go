@@ -0,0 +1,375 @@
+package bulkops_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/bulkops"
+)
+
+type requestEvent struct {
+	Method string
+	Path   string
+	Start  bool
+}
+
+// concurrencyTrackingServer records the start and end of every request and the highest number of
+// requests in flight at once. Each request takes a short while so concurrent batches overlap.
+func concurrencyTrackingServer(t *testing.T, shouldFail func(*http.Request) bool) (*httptest.Server, func() ([]requestEvent, int)) {
+	t.Helper()
+	var mu sync.Mutex
+	var events []requestEvent
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		events = append(events, requestEvent{Method: r.Method, Path: r.URL.Path, Start: true})
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		if r.Method != http.MethodGet {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		mu.Lock()
+		events = append(events, requestEvent{Method: r.Method, Path: r.URL.Path, Start: false})
+		inFlight--
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if shouldFail != nil && shouldFail(r) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"simulated failure"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() ([]requestEvent, int) {
+		mu.Lock()
+		defer mu.Unlock()
+		out := make([]requestEvent, len(events))
+		copy(out, events)
+		return out, maxInFlight
+	}
+}
+
+// eventIndex returns the index of the first start or end event for method and path, or -1.
+func eventIndex(events []requestEvent, method, path string, start bool) int {
+	for i, e := range events {
+		if e.Method == method && e.Path == path && e.Start == start {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestParallelBatchesRespectDependencies(t *testing.T) {
+	t.Parallel()
+	server, snapshot := concurrencyTrackingServer(t, nil)
+	client := newTestClient(server.URL)
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.MaxParallelBatches = 4
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	addPutsForResources(ctx, mgr, dcPutOrder)
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if diags.HasError() {
+		t.Fatalf("ExecuteDatacenterOperations returned errors: %v", diags)
+	}
+
+	events, maxInFlight := snapshot()
+	if maxInFlight < 2 {
+		t.Errorf("expected independent batches to overlap, max in flight was %d", maxInFlight)
+	}
+	if maxInFlight > 4 {
+		t.Errorf("expected at most 4 batches in flight, got %d", maxInFlight)
+	}
+
+	// Each pair is {referenced, referencing}: the referencing PUT must start after the referenced PUT ended.
+	for _, pair := range [][2]string{
+		{"route_map_clause", "route_map"},
+		{"route_map", "tenant"},
+		{"tenant", "service"},
+		{"ipv4_list", "pb_routing_acl"},
+		{"service", "eth_port_profile"},
+		{"bundle", "switchpoint"},
+		{"switchpoint", "device_controller"},
+	} {
+		done := eventIndex(events, http.MethodPut, resourceAPIPath[pair[0]], false)
+		started := eventIndex(events, http.MethodPut, resourceAPIPath[pair[1]], true)
+		if done == -1 || started == -1 {
+			t.Errorf("expected PUTs for both %s and %s", pair[0], pair[1])
+			continue
+		}
+		if started < done {
+			t.Errorf("%s PUT started before %s PUT finished", pair[1], pair[0])
+		}
+	}
+}
+
+func TestParallelDeletesRunInReverseDependencyOrder(t *testing.T) {
+	t.Parallel()
+	server, snapshot := concurrencyTrackingServer(t, nil)
+	client := newTestClient(server.URL)
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "campus")
+	settings := mgr.Settings()
+	settings.MaxParallelBatches = 8
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	addDeletesForResources(ctx, mgr, campusDeleteOrder)
+
+	diags, _ := mgr.ExecuteCampusOperations(ctx)
+	if diags.HasError() {
+		t.Fatalf("ExecuteCampusOperations returned errors: %v", diags)
+	}
+
+	events, _ := snapshot()
+	for _, pair := range [][2]string{
+		{"device_controller", "switchpoint"},
+		{"switchpoint", "bundle"},
+		{"authenticated_eth_port", "eth_port_profile"},
+		{"service", "pb_routing"},
+	} {
+		done := eventIndex(events, http.MethodDelete, resourceAPIPath[pair[0]], false)
+		started := eventIndex(events, http.MethodDelete, resourceAPIPath[pair[1]], true)
+		if done == -1 || started == -1 {
+			t.Errorf("expected DELETEs for both %s and %s", pair[0], pair[1])
+			continue
+		}
+		if started < done {
+			t.Errorf("%s DELETE started before %s DELETE finished", pair[1], pair[0])
+		}
+	}
+}
+
+func TestParallelBatchFailureAbortsLaterLevels(t *testing.T) {
+	t.Parallel()
+	server, snapshot := concurrencyTrackingServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodPut && r.URL.Path == "/routemapclauses"
+	})
+	client := newTestClient(server.URL)
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.MaxParallelBatches = 8
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	mgr.AddPut(ctx, "community_list", "test_cl", zeroPutValue("community_list"))
+	mgr.AddPut(ctx, "route_map_clause", "test_clause", zeroPutValue("route_map_clause"))
+	mgr.AddPut(ctx, "pb_routing_acl", "test_pbr_acl", zeroPutValue("pb_routing_acl"))
+	// These depend on route_map_clause (directly or not) — should NOT execute
+	routeMapOp := mgr.AddPut(ctx, "route_map", "test_rm", zeroPutValue("route_map"))
+	mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+	// service does not reference route_map_clause but is in a later level — should NOT execute either
+	serviceOp := mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed route_map_clause PUT, got none")
+	}
+
+	events, _ := snapshot()
+	for _, path := range []string{"/routemaps", "/tenants", "/services"} {
+		if eventIndex(events, http.MethodPut, path, true) != -1 {
+			t.Errorf("path %q should NOT have been called after route_map_clause failure", path)
+		}
+	}
+	for _, path := range []string{"/communitylists", "/routemapclauses"} {
+		if eventIndex(events, http.MethodPut, path, true) == -1 {
+			t.Errorf("expected PUT to %q", path)
+		}
+	}
+
+	for _, opID := range []string{routeMapOp, serviceOp} {
+		if err := mgr.WaitForOperation(ctx, opID, time.Second); err == nil {
+			t.Errorf("expected operation %s to be failed after route_map_clause failure", opID)
+		}
+	}
+}
+
+func TestContinueOnErrorCancelsOnlyDependents(t *testing.T) {
+	t.Parallel()
+	server, snapshot := concurrencyTrackingServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodPut && r.URL.Path == "/routemapclauses"
+	})
+	client := newTestClient(server.URL)
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.ContinueOnError = true
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	mgr.AddPut(ctx, "community_list", "test_cl", zeroPutValue("community_list"))
+	mgr.AddPut(ctx, "route_map_clause", "test_clause", zeroPutValue("route_map_clause"))
+	// These depend on route_map_clause (directly or not) — should be cancelled
+	routeMapOp := mgr.AddPut(ctx, "route_map", "test_rm", zeroPutValue("route_map"))
+	serviceOp := mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
+	// pb_routing is in a later level but does not reference route_map_clause — should still execute
+	pbRoutingOp := mgr.AddPut(ctx, "pb_routing", "test_pbr", zeroPutValue("pb_routing"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed route_map_clause PUT, got none")
+	}
+
+	events, _ := snapshot()
+	for _, path := range []string{"/routemaps", "/services"} {
+		if eventIndex(events, http.MethodPut, path, true) != -1 {
+			t.Errorf("path %q should NOT have been called after route_map_clause failure", path)
+		}
+	}
+	for _, path := range []string{"/communitylists", "/routemapclauses", "/policybasedrouting"} {
+		if eventIndex(events, http.MethodPut, path, true) == -1 {
+			t.Errorf("expected PUT to %q", path)
+		}
+	}
+
+	for _, opID := range []string{routeMapOp, serviceOp} {
+		if err := mgr.WaitForOperation(ctx, opID, time.Second); err == nil {
+			t.Errorf("expected operation %s to be cancelled after route_map_clause failure", opID)
+		}
+	}
+	if err := mgr.WaitForOperation(ctx, pbRoutingOp, time.Second); err != nil {
+		t.Errorf("expected pb_routing PUT to succeed, got %v", err)
+	}
+}
+
+func TestContinueOnErrorCancelsDeletesOfReferencedTypes(t *testing.T) {
+	t.Parallel()
+	server, snapshot := concurrencyTrackingServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodDelete && r.URL.Path == "/tenants"
+	})
+	client := newTestClient(server.URL)
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.ContinueOnError = true
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	mgr.AddDelete(ctx, "service", "test_service")
+	mgr.AddDelete(ctx, "tenant", "test_tenant")
+	// the failed tenant may still reference its route map — should not be deleted
+	routeMapOp := mgr.AddDelete(ctx, "route_map", "test_rm")
+	badgeOp := mgr.AddDelete(ctx, "badge", "test_badge")
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed tenant DELETE, got none")
+	}
+
+	events, _ := snapshot()
+	if eventIndex(events, http.MethodDelete, "/routemaps", true) != -1 {
+		t.Error("route_map DELETE should NOT have been called after tenant failure")
+	}
+	for _, path := range []string{"/services", "/tenants", "/badges"} {
+		if eventIndex(events, http.MethodDelete, path, true) == -1 {
+			t.Errorf("expected DELETE to %q", path)
+		}
+	}
+
+	if err := mgr.WaitForOperation(ctx, routeMapOp, time.Second); err == nil {
+		t.Error("expected route_map DELETE to be cancelled after tenant failure")
+	}
+	if err := mgr.WaitForOperation(ctx, badgeOp, time.Second); err != nil {
+		t.Errorf("expected badge DELETE to succeed, got %v", err)
+	}
+}
+
+// firstBadgePutFailingServer answers the first badge PUT with a server error and every other
+// request with success, and counts the badge PUTs.
+func firstBadgePutFailingServer(t *testing.T) (*httptest.Server, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	puts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut && r.URL.Path == "/badges" {
+			mu.Lock()
+			puts++
+			first := puts == 1
+			mu.Unlock()
+			if first {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"payload":"internal error"}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return puts
+	}
+}
+
+func TestFailedBatchOfSplitOperation(t *testing.T) {
+	t.Parallel()
+	for _, continueOnError := range []bool{true, false} {
+		t.Run(fmt.Sprintf("continue_on_error=%v", continueOnError), func(t *testing.T) {
+			t.Parallel()
+			server, puts := firstBadgePutFailingServer(t)
+			mgr := bulkops.GetManager(newTestClient(server.URL), nopClearCache, nil, "datacenter")
+			settings := mgr.Settings()
+			settings.MaxBatchSize = 2
+			settings.ContinueOnError = continueOnError
+			mgr.SetSettings(settings)
+
+			ctx := context.Background()
+			var opIDs []string
+			for i := 0; i < 5; i++ {
+				opIDs = append(opIDs, mgr.AddPut(ctx, "badge", fmt.Sprintf("badge_%d", i), zeroPutValue("badge")))
+			}
+
+			diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+			if !diags.HasError() {
+				t.Fatal("expected an error from the failed batch, got none")
+			}
+
+			// an operation left pending would wait forever
+			waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			failed := 0
+			for _, opID := range opIDs {
+				err := mgr.WaitForOperation(waitCtx, opID, time.Second)
+				if err == context.DeadlineExceeded {
+					t.Fatalf("operation %s was left pending", opID)
+				}
+				if err != nil {
+					failed++
+				}
+			}
+
+			// in continue mode the other batches are still sent, otherwise they are not sent and fail
+			wantPuts, wantFailed := 3, 2
+			if !continueOnError {
+				wantPuts, wantFailed = 1, 5
+			}
+			if got := puts(); got != wantPuts {
+				t.Errorf("expected %d badge PUT requests, got %d", wantPuts, got)
+			}
+			if failed != wantFailed {
+				t.Errorf("expected %d failed operations, got %d", wantFailed, failed)
+			}
+		})
+	}
+}
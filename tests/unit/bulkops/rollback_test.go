@@ -0,0 +1,231 @@
+package bulkops_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/bulkops"
+	"terraform-provider-verity/openapi"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// rollbackServer serves the badge b1 with color red, fails the requests matching
+// shouldFail and records every write request.
+func rollbackServer(t *testing.T, shouldFail func(*http.Request) bool) (*openapi.APIClient, func() []recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var requests []recordedRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			if r.URL.Path == "/badges" {
+				w.Write([]byte(`{"badge":{"b1":{"name":"b1","color":"red"}}}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+		mu.Unlock()
+
+		if shouldFail(r) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"simulated failure"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return newTestClient(server.URL), func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func rollbackManager(client *openapi.APIClient) *bulkops.Manager {
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.RollbackOnFailure = true
+	mgr.SetSettings(settings)
+	return mgr
+}
+
+func TestRollbackDeletesCreatedObjects(t *testing.T) {
+	t.Parallel()
+	client, requests := rollbackServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodPut && r.URL.Path == "/services"
+	})
+	mgr := rollbackManager(client)
+
+	ctx := context.Background()
+	tenantOp := mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+	mgr.AddPut(ctx, "service", "test_service", zeroPutValue("service"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed service PUT, got none")
+	}
+
+	recorded := requests()
+	last := recorded[len(recorded)-1]
+	if last.Method != http.MethodDelete || last.Path != "/tenants" || !strings.Contains(last.Query, "test_tenant") {
+		t.Errorf("expected the created tenant to be deleted last, got %+v", recorded)
+	}
+
+	err := mgr.WaitForOperation(ctx, tenantOp, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Errorf("expected tenant PUT to fail as rolled back, got %v", err)
+	}
+}
+
+func TestRollbackRestoresUpdatedObjects(t *testing.T) {
+	t.Parallel()
+	client, requests := rollbackServer(t, func(r *http.Request) bool {
+		return r.Method == http.MethodPatch && r.URL.Path == "/tenants"
+	})
+	mgr := rollbackManager(client)
+
+	ctx := context.Background()
+	badge := openapi.NewBadgesPutRequestBadgeValue()
+	badge.SetColor("blue")
+	badgeOp := mgr.AddPatch(ctx, "badge", "b1", *badge)
+	mgr.AddPatch(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed tenant PATCH, got none")
+	}
+
+	var badgePatches []string
+	for _, r := range requests() {
+		if r.Method == http.MethodPatch && r.Path == "/badges" {
+			badgePatches = append(badgePatches, r.Body)
+		}
+	}
+	if len(badgePatches) != 2 || !strings.Contains(badgePatches[0], "blue") || !strings.Contains(badgePatches[1], `"color":"red"`) {
+		t.Errorf("expected the badge to be patched to blue and back to red, got %v", badgePatches)
+	}
+
+	if err := mgr.WaitForOperation(ctx, badgeOp, time.Second); err == nil {
+		t.Error("expected badge PATCH to fail as rolled back")
+	}
+}
+
+func TestRollbackKeepsSuccessfulRuns(t *testing.T) {
+	t.Parallel()
+	client, requests := rollbackServer(t, func(r *http.Request) bool { return false })
+	mgr := rollbackManager(client)
+
+	ctx := context.Background()
+	tenantOp := mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if err := mgr.WaitForOperation(ctx, tenantOp, time.Second); err != nil {
+		t.Errorf("expected tenant PUT to succeed, got %v", err)
+	}
+	for _, r := range requests() {
+		if r.Method == http.MethodDelete {
+			t.Errorf("expected no rollback, got %+v", r)
+		}
+	}
+}
+
+func TestRollbackRemovesAddedListEntries(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	externalGateways := `[{"index":1,"gateway":"gw1"}]`
+	var gatewayProfilePatches []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/gatewayprofiles":
+			w.Write([]byte(`{"gateway_profile":{"gp1":{"name":"gp1","external_gateways":` + externalGateways + `}}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/gatewayprofiles":
+			body, _ := io.ReadAll(r.Body)
+			gatewayProfilePatches = append(gatewayProfilePatches, string(body))
+			// the update adds a second gateway
+			externalGateways = `[{"index":1,"gateway":"gw1"},{"index":2,"gateway":"gw2"}]`
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/lags":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"simulated failure"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	mgr := rollbackManager(newTestClient(server.URL))
+
+	ctx := context.Background()
+	profile := openapi.NewGatewayprofilesPutRequestGatewayProfileValue()
+	gateway := openapi.GatewayprofilesPutRequestGatewayProfileValueExternalGatewaysInner{}
+	gateway.SetGateway("gw2")
+	profile.SetExternalGateways([]openapi.GatewayprofilesPutRequestGatewayProfileValueExternalGatewaysInner{gateway})
+	mgr.AddPatch(ctx, "gateway_profile", "gp1", *profile)
+	mgr.AddPatch(ctx, "lag", "test_lag", zeroPutValue("lag"))
+
+	diags, _ := mgr.ExecuteDatacenterOperations(ctx)
+	if !diags.HasError() {
+		t.Fatal("expected errors from failed lag PATCH, got none")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(gatewayProfilePatches) != 2 {
+		t.Fatalf("expected the gateway profile to be patched and rolled back, got %v", gatewayProfilePatches)
+	}
+	undo := gatewayProfilePatches[1]
+	if !strings.Contains(undo, `"gateway":"gw1"`) || !strings.Contains(undo, `{"index":2}`) {
+		t.Errorf("expected the rollback to restore gw1 and remove the added entry 2, got %s", undo)
+	}
+}
+
+func TestRollbackHeldOperationsDoNotTimeOut(t *testing.T) {
+	t.Parallel()
+	client, _ := rollbackServer(t, func(r *http.Request) bool {
+		// the tenant batch outlasts the timeout the badge operation is waited for with
+		if r.Method == http.MethodPut && r.URL.Path == "/tenants" {
+			time.Sleep(300 * time.Millisecond)
+		}
+		return false
+	})
+	mgr := rollbackManager(client)
+
+	ctx := context.Background()
+	badgeOp := mgr.AddPut(ctx, "badge", "test_badge", zeroPutValue("badge"))
+	mgr.AddPut(ctx, "tenant", "test_tenant", zeroPutValue("tenant"))
+
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- mgr.WaitForOperation(ctx, badgeOp, 100*time.Millisecond) }()
+
+	if diags, _ := mgr.ExecuteDatacenterOperations(ctx); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if err := <-waitErr; err != nil {
+		t.Errorf("expected the held badge PUT to succeed, got %v", err)
+	}
+}
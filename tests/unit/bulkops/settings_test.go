@@ -0,0 +1,131 @@
+package bulkops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/bulkops"
+	"terraform-provider-verity/openapi"
+)
+
+// badgeBatchServer records the number of badges in every PUT body and DELETE query.
+func badgeBatchServer(t *testing.T) (*openapi.APIClient, func(method string) []int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	batches := map[string][]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/badges" && r.Method == http.MethodPut:
+			var body struct {
+				Badge map[string]interface{} `json:"badge"`
+			}
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &body)
+			mu.Lock()
+			batches[r.Method] = append(batches[r.Method], len(body.Badge))
+			mu.Unlock()
+		case r.URL.Path == "/api/badges" && r.Method == http.MethodDelete:
+			mu.Lock()
+			batches[r.Method] = append(batches[r.Method], len(r.URL.Query()["badge_name"]))
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"badge":{}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := openapi.NewConfiguration()
+	cfg.Servers = openapi.ServerConfigurations{{URL: server.URL + "/api"}}
+	cfg.HTTPClient = &http.Client{}
+
+	return openapi.NewAPIClient(cfg), func(method string) []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), batches[method]...)
+	}
+}
+
+func TestSettings_BatchSizes(t *testing.T) {
+	t.Parallel()
+	client, batchSizes := badgeBatchServer(t)
+
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	settings := mgr.Settings()
+	settings.MaxBatchSize = 2
+	settings.MaxDeleteBatchSize = 3
+	mgr.SetSettings(settings)
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		mgr.AddPut(ctx, "badge", fmt.Sprintf("badge_put_%d", i), zeroPutValue("badge"))
+		mgr.AddDelete(ctx, "badge", fmt.Sprintf("badge_delete_%d", i))
+	}
+
+	if diags := mgr.ExecuteBulk(ctx, "badge", "PUT"); diags.HasError() {
+		t.Fatalf("unexpected PUT error: %v", diags)
+	}
+	if diags := mgr.ExecuteBulk(ctx, "badge", "DELETE"); diags.HasError() {
+		t.Fatalf("unexpected DELETE error: %v", diags)
+	}
+
+	if got := fmt.Sprint(batchSizes(http.MethodPut)); got != "[2 2 1]" {
+		t.Errorf("expected PUT batches of [2 2 1] badges, got %s", got)
+	}
+	if got := fmt.Sprint(batchSizes(http.MethodDelete)); got != "[3 2]" {
+		t.Errorf("expected DELETE batches of [3 2] badges, got %s", got)
+	}
+}
+
+func TestSettings_InvalidValuesKeepDefaults(t *testing.T) {
+	t.Parallel()
+	client, _ := badgeBatchServer(t)
+
+	mgr := bulkops.GetManager(client, nopClearCache, nil, "datacenter")
+	mgr.SetSettings(bulkops.Settings{})
+
+	defaults := bulkops.DefaultSettings()
+	got := mgr.Settings()
+	if got.MaxBatchSize != defaults.MaxBatchSize || got.MaxDeleteBatchSize != defaults.MaxDeleteBatchSize ||
+		got.DebounceDelay != defaults.DebounceDelay || got.BatchCollectionWindow != defaults.BatchCollectionWindow {
+		t.Errorf("expected zero settings to keep the defaults %+v, got %+v", defaults, got)
+	}
+}
+
+func TestSettings_AdaptiveDebounceDelay(t *testing.T) {
+	t.Parallel()
+
+	settings := bulkops.Settings{
+		BatchCollectionWindow: 2 * time.Second,
+		DebounceDelay:         15 * time.Second,
+	}
+	if got := settings.AdaptiveDebounceDelay(0); got != 15*time.Second {
+		t.Errorf("expected the full debounce delay without adaptive debounce, got %v", got)
+	}
+
+	settings.AdaptiveDebounce = true
+	tests := []struct {
+		longestGap time.Duration
+		expected   time.Duration
+	}{
+		// a single resource, or resources queued together, wait one collection window
+		{0, 2 * time.Second},
+		{500 * time.Millisecond, 2 * time.Second},
+		{3 * time.Second, 6 * time.Second},
+		{10 * time.Second, 15 * time.Second},
+	}
+	for _, tc := range tests {
+		if got := settings.AdaptiveDebounceDelay(tc.longestGap); got != tc.expected {
+			t.Errorf("longest gap %v: expected delay %v, got %v", tc.longestGap, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package datasource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/internal/provider"
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+// pluralDataSources maps the singular object data sources to their plural counterparts
+// where the plural name is not simply the singular name with an "s" appended.
+var pluralDataSources = map[string]string{
+	"verity_acl_v4":                "verity_acls_v4",
+	"verity_acl_v6":                "verity_acls_v6",
+	"verity_device_settings":       "verity_device_settings_list",
+	"verity_device_voice_settings": "verity_device_voice_settings_list",
+	"verity_eth_port_settings":     "verity_eth_port_settings_list",
+}
+
+// configuredServer returns a provider server configured against a mock server loaded with the
+// response fixtures of the given mode, along with the provider schemas.
+func configuredServer(t *testing.T, mode string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	ms := mock.NewMockServer(mode)
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir(mode)); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+
+	server, err := providerserver.NewProtocol6WithError(provider.New("test")())()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %v", err)
+	}
+	failOnDiagnostics(t, "GetProviderSchema", schemas.Diagnostics)
+
+	configResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: objectValue(t, schemas.Provider, map[string]tftypes.Value{
+			"uri":      tftypes.NewValue(tftypes.String, ms.URL()),
+			"username": tftypes.NewValue(tftypes.String, "test"),
+			"password": tftypes.NewValue(tftypes.String, "test"),
+			"mode":     tftypes.NewValue(tftypes.String, mode),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to configure provider: %v", err)
+	}
+	failOnDiagnostics(t, "ConfigureProvider", configResp.Diagnostics)
+
+	return server, schemas
+}
+
+// objectValue builds a config value for the schema, leaving unset attributes null.
+func objectValue(t *testing.T, s *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	objectType := s.ValueType().(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attributes[name] = v
+		} else {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	dv, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatalf("failed to build config value: %v", err)
+	}
+	return &dv
+}
+
+func readDataSource(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, values map[string]tftypes.Value) map[string]tftypes.Value {
+	t.Helper()
+	s, ok := schemas.DataSourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("data source %s is not registered", typeName)
+	}
+
+	resp, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   objectValue(t, s, values),
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", typeName, err)
+	}
+	failOnDiagnostics(t, typeName, resp.Diagnostics)
+
+	state, err := resp.State.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatalf("failed to decode %s state: %v", typeName, err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("failed to decode %s attributes: %v", typeName, err)
+	}
+	return attributes
+}
+
+func stringList(t *testing.T, v tftypes.Value) []string {
+	t.Helper()
+	var elements []tftypes.Value
+	if err := v.As(&elements); err != nil {
+		t.Fatalf("failed to decode list: %v", err)
+	}
+	result := make([]string, len(elements))
+	for i, e := range elements {
+		if err := e.As(&result[i]); err != nil {
+			t.Fatalf("failed to decode list element: %v", err)
+		}
+	}
+	return result
+}
+
+func failOnDiagnostics(t *testing.T, operation string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", operation, d.Summary, d.Detail)
+		}
+	}
+}
+
+// TestObjectDataSources_ListAndLookup lists every resource type available in the mode through
+// its plural data source and looks up the first object through the singular data source.
+func TestObjectDataSources_ListAndLookup(t *testing.T) {
+	for _, mode := range []string{"datacenter", "campus"} {
+		t.Run(mode, func(t *testing.T) {
+			server, schemas := configuredServer(t, mode)
+
+			checked := 0
+			for resourceType := range schemas.ResourceSchemas {
+				singular := resourceType
+				if _, ok := schemas.DataSourceSchemas[singular]; !ok || singular == "verity_switchpoint_current_config" {
+					continue
+				}
+				if !utils.IsResourceCompatibleWithMode(singular, mode) {
+					continue
+				}
+				plural, ok := pluralDataSources[singular]
+				if !ok {
+					plural = singular + "s"
+				}
+
+				t.Run(strings.TrimPrefix(singular, "verity_"), func(t *testing.T) {
+					listed := readDataSource(t, server, schemas, plural, nil)
+					names := stringList(t, listed["names"])
+
+					var items []tftypes.Value
+					if err := listed["items"].As(&items); err != nil {
+						t.Fatalf("failed to decode items: %v", err)
+					}
+					if len(items) != len(names) {
+						t.Fatalf("expected %d items, got %d", len(names), len(items))
+					}
+					if len(names) == 0 {
+						return
+					}
+
+					object := readDataSource(t, server, schemas, singular, map[string]tftypes.Value{
+						"name": tftypes.NewValue(tftypes.String, names[0]),
+					})
+					var name string
+					if err := object["name"].As(&name); err != nil || name != names[0] {
+						t.Errorf("expected name %q, got %q", names[0], name)
+					}
+				})
+				checked++
+			}
+
+			if checked == 0 {
+				t.Fatal("no object data sources were checked")
+			}
+		})
+	}
+}
+
+func TestObjectDataSources_Filters(t *testing.T) {
+	server, schemas := configuredServer(t, "datacenter")
+
+	all := stringList(t, readDataSource(t, server, schemas, "verity_route_maps", nil)["names"])
+	if len(all) < 2 {
+		t.Fatalf("expected at least 2 route maps in the fixtures, got %d", len(all))
+	}
+
+	byName := stringList(t, readDataSource(t, server, schemas, "verity_route_maps", map[string]tftypes.Value{
+		"name_regex": tftypes.NewValue(tftypes.String, "^"+all[0]+"$"),
+	})["names"])
+	if len(byName) != 1 || byName[0] != all[0] {
+		t.Errorf("expected name_regex to match only %q, got %v", all[0], byName)
+	}
+
+	noGroup := stringList(t, readDataSource(t, server, schemas, "verity_route_maps", map[string]tftypes.Value{
+		"group": tftypes.NewValue(tftypes.String, "no-such-group"),
+	})["names"])
+	if len(noGroup) != 0 {
+		t.Errorf("expected no route maps in group no-such-group, got %v", noGroup)
+	}
+}
@@ -82,6 +82,15 @@ var allResourceTests = []ResourceCoverageEntry{
 		ResourceName:  "cov_site",
 		SkipCreate:    true, // Site is update-only
 	},
+	{
+		TerraformType: "verity_image_update_set",
+		Factory:       provider.NewVerityImageUpdateSetResource,
+		APIPath:       "/api/imageupdatesets",
+		WrapperKey:    "image_update_sets",
+		Mode:          "datacenter",
+		ResourceName:  "cov_ius",
+		SkipCreate:    true, // Image Update Set is update-only
+	},
 	{
 		TerraformType: "verity_eth_port_profile",
 		Factory:       provider.NewVerityEthPortProfileResource,
@@ -420,6 +429,11 @@ func inspectSchema(factory func() resource.Resource) resourceSchemaInfo {
 
 	var rs resourceSchemaInfo
 	for name, attr := range resp.Schema.Attributes {
+		// Write-only companions are sent in place of their credential attribute and
+		// conflict with it, so they are not part of the generated config
+		if attr.IsWriteOnly() || strings.HasSuffix(name, utils.WriteOnlyVersionAttrSuffix) {
+			continue
+		}
 		fi := fieldInfo{Name: name, Type: attrFieldType(attr)}
 		if sa, ok := attr.(fwschema.StringAttribute); ok {
 			fi.Required = sa.Required
@@ -0,0 +1,191 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+// actionServer returns a provider server configured against a datacenter mock server.
+func actionServer(t *testing.T) (*mock.MockServer, tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	t.Setenv("VERITY_TF_WORKDIR", t.TempDir())
+
+	ms := mock.NewMockServer("datacenter")
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir("datacenter")); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter")))
+	return ms, server, schemas
+}
+
+func nameSet(names ...string) tftypes.Value {
+	elements := make([]tftypes.Value, 0, len(names))
+	for _, name := range names {
+		elements = append(elements, tftypes.NewValue(tftypes.String, name))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+}
+
+func stateAttribute(t *testing.T, state tftypes.Value, name string) tftypes.Value {
+	t.Helper()
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	return attributes[name]
+}
+
+// requestedDevices returns the device names of every request sent to path, in order.
+func requestedDevices(t *testing.T, ms *mock.MockServer, method, path string) [][]string {
+	t.Helper()
+	var devices [][]string
+	for _, req := range ms.GetRequestsByMethodAndPath(method, path) {
+		raw, _ := req.Body["device_names"].([]interface{})
+		names := make([]string, 0, len(raw))
+		for _, name := range raw {
+			names = append(names, name.(string))
+		}
+		sort.Strings(names)
+		devices = append(devices, names)
+	}
+	return devices
+}
+
+func TestSwitchpointUpgrade_Apply(t *testing.T) {
+	ms, server, schemas := actionServer(t)
+	const typeName = "verity_switchpoint_upgrade"
+	objectType := schemas.ResourceSchemas[typeName].ValueType()
+
+	state, diags := mock.ApplyResource(t, server, schemas, typeName, tftypes.NewValue(objectType, nil), map[string]tftypes.Value{
+		"package_version": tftypes.NewValue(tftypes.String, "6.5.1"),
+		"device_names":    nameSet("sw2", "sw1"),
+	})
+	mock.FailOnDiagnostics(t, "Create", diags)
+	id := stateAttribute(t, state, "id")
+
+	requests := ms.GetRequestsByMethodAndPath("PATCH", "/api/switchpoints/upgrade")
+	if len(requests) != 1 || requests[0].Body["package_version"] != "6.5.1" {
+		t.Fatalf("expected one upgrade to 6.5.1, got %+v", requests)
+	}
+
+	// adding a switchpoint only upgrades that switchpoint and keeps the id known in the plan
+	values := map[string]tftypes.Value{
+		"package_version": tftypes.NewValue(tftypes.String, "6.5.1"),
+		"device_names":    nameSet("sw1", "sw2", "sw3"),
+	}
+	s := schemas.ResourceSchemas[typeName]
+	prior, err := tfprotov6.NewDynamicValue(objectType, state)
+	if err != nil {
+		t.Fatalf("failed to build prior state: %v", err)
+	}
+	config := mock.ObjectValue(t, s, values)
+	planResp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &prior,
+		ProposedNewState: mock.ObjectValue(t, s, map[string]tftypes.Value{"id": id, "package_version": values["package_version"], "device_names": values["device_names"]}),
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan update: %v", err)
+	}
+	mock.FailOnDiagnostics(t, "Plan", planResp.Diagnostics)
+	planned, err := planResp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("failed to decode plan: %v", err)
+	}
+	if plannedID := stateAttribute(t, planned, "id"); !plannedID.Equal(id) {
+		t.Errorf("expected the planned id to stay %v, got %v", id, plannedID)
+	}
+
+	state, diags = mock.ApplyResource(t, server, schemas, typeName, state, values)
+	mock.FailOnDiagnostics(t, "Update", diags)
+
+	// a new version is rolled out to every switchpoint
+	values["package_version"] = tftypes.NewValue(tftypes.String, "6.6.0")
+	state, diags = mock.ApplyResource(t, server, schemas, typeName, state, values)
+	mock.FailOnDiagnostics(t, "Update", diags)
+
+	want := [][]string{{"sw1", "sw2"}, {"sw3"}, {"sw1", "sw2", "sw3"}}
+	if got := requestedDevices(t, ms, "PATCH", "/api/switchpoints/upgrade"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected upgrades of %v, got %v", want, got)
+	}
+	if got := stateAttribute(t, state, "id"); !got.Equal(id) {
+		t.Errorf("expected the id to stay %v, got %v", id, got)
+	}
+}
+
+func TestSwitchpointMaintenance_Apply(t *testing.T) {
+	ms, server, schemas := actionServer(t)
+	const typeName = "verity_switchpoint_maintenance"
+	objectType := schemas.ResourceSchemas[typeName].ValueType()
+
+	state, diags := mock.ApplyResource(t, server, schemas, typeName, tftypes.NewValue(objectType, nil), map[string]tftypes.Value{
+		"device_names": nameSet("sw1", "sw2"),
+	})
+	mock.FailOnDiagnostics(t, "Create", diags)
+
+	state, diags = mock.ApplyResource(t, server, schemas, typeName, state, map[string]tftypes.Value{
+		"device_names": nameSet("sw2", "sw3"),
+	})
+	mock.FailOnDiagnostics(t, "Update", diags)
+
+	_, diags = mock.ApplyResource(t, server, schemas, typeName, state, nil)
+	mock.FailOnDiagnostics(t, "Delete", diags)
+
+	var got []string
+	for _, req := range ms.GetRequestsByMethodAndPath("PUT", "/api/switchpoints/markoutofservice") {
+		raw, _ := req.Body["device_names"].([]interface{})
+		names := make([]string, 0, len(raw))
+		for _, name := range raw {
+			names = append(names, name.(string))
+		}
+		sort.Strings(names)
+		got = append(got, fmt.Sprintf("mos=%v %v", req.Body["mos"], names))
+	}
+	want := []string{"mos=true [sw1 sw2]", "mos=true [sw3]", "mos=false [sw1]", "mos=false [sw2 sw3]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected requests %v, got %v", want, got)
+	}
+}
+
+func TestSwitchpointMaintenance_ReadDropsSwitchpointsBackInService(t *testing.T) {
+	ms, server, schemas := actionServer(t)
+	const typeName = "verity_switchpoint_maintenance"
+	objectType := schemas.ResourceSchemas[typeName].ValueType()
+	ms.SetGetResponse("/api/switchpoints/markoutofservice", []byte(`{"switchpoint":{"sw2":{"mos":true}}}`))
+
+	current, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "sw1,sw2"),
+		"device_names": nameSet("sw1", "sw2"),
+	}))
+	if err != nil {
+		t.Fatalf("failed to build state: %v", err)
+	}
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     typeName,
+		CurrentState: &current,
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", typeName, err)
+	}
+	mock.FailOnDiagnostics(t, "Read", resp.Diagnostics)
+
+	state, err := resp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	if got := stateAttribute(t, state, "device_names"); !got.Equal(nameSet("sw2")) {
+		t.Errorf("expected only sw2 to remain out of service, got %v", got)
+	}
+}
@@ -0,0 +1,180 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/internal/provider"
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+var writeOnlyCredentials = []struct {
+	TerraformType string
+	Factory       func() resource.Resource
+	Attributes    []string
+}{
+	{
+		TerraformType: "verity_device_controller",
+		Factory:       provider.NewVerityDeviceControllerResource,
+		Attributes:    []string{"password", "enable_password", "ssh_key_or_password", "passphrase", "private_password"},
+	},
+	{
+		TerraformType: "verity_gateway",
+		Factory:       provider.NewVerityGatewayResource,
+		Attributes:    []string{"md5_password"},
+	},
+}
+
+func resourceSchema(t *testing.T, factory func() resource.Resource) fwschema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	factory().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+func TestWriteOnlyCredentials_Schema(t *testing.T) {
+	for _, tc := range writeOnlyCredentials {
+		t.Run(tc.TerraformType, func(t *testing.T) {
+			s := resourceSchema(t, tc.Factory)
+			for _, name := range tc.Attributes {
+				if attr, ok := s.Attributes[name]; !ok || !attr.IsSensitive() {
+					t.Errorf("%s should be sensitive", name)
+				}
+
+				wo, ok := s.Attributes[name+"_wo"]
+				if !ok {
+					t.Errorf("%s_wo is missing", name)
+					continue
+				}
+				if !wo.IsWriteOnly() || !wo.IsSensitive() || !wo.IsOptional() {
+					t.Errorf("%s_wo should be optional, sensitive and write-only", name)
+				}
+
+				if _, ok := s.Attributes[name+"_wo_version"].(fwschema.Int64Attribute); !ok {
+					t.Errorf("%s_wo_version should be an Int64 attribute", name)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteOnlyCredentials_Validation(t *testing.T) {
+	for _, tc := range writeOnlyCredentials {
+		t.Run(tc.TerraformType, func(t *testing.T) {
+			s := resourceSchema(t, tc.Factory)
+			objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+
+			configWith := func(values map[string]string, versions ...string) tfsdk.Config {
+				attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+				for name, attrType := range objectType.AttributeTypes {
+					attributes[name] = tftypes.NewValue(attrType, nil)
+				}
+				for name, value := range values {
+					attributes[name] = tftypes.NewValue(tftypes.String, value)
+				}
+				for _, name := range versions {
+					attributes[name] = tftypes.NewValue(tftypes.Number, 1)
+				}
+				return tfsdk.Config{Schema: s, Raw: tftypes.NewValue(objectType, attributes)}
+			}
+
+			validator, ok := tc.Factory().(resource.ResourceWithValidateConfig)
+			if !ok {
+				t.Fatalf("%s does not validate its config", tc.TerraformType)
+			}
+
+			for _, name := range tc.Attributes {
+				var resp resource.ValidateConfigResponse
+				validator.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+					Config: configWith(map[string]string{"name": "test", name + "_wo": "secret"}, name+"_wo_version"),
+				}, &resp)
+				if resp.Diagnostics.HasError() {
+					t.Errorf("%s_wo with its version should be valid: %v", name, resp.Diagnostics)
+				}
+
+				resp = resource.ValidateConfigResponse{}
+				validator.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+					Config: configWith(map[string]string{"name": "test", name + "_wo": "secret"}),
+				}, &resp)
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("%s_wo without %s_wo_version should be rejected", name, name)
+				}
+
+				resp = resource.ValidateConfigResponse{}
+				validator.ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+					Config: configWith(map[string]string{"name": "test", name: "secret", name + "_wo": "secret"}, name+"_wo_version"),
+				}, &resp)
+				if !resp.Diagnostics.HasError() {
+					t.Errorf("%s and %s_wo together should be rejected", name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteOnlyCredentials_Apply(t *testing.T) {
+	ms, server, schemas := actionServer(t)
+	const typeName = "verity_gateway"
+	objectType := schemas.ResourceSchemas[typeName].ValueType()
+
+	values := map[string]tftypes.Value{
+		"name":                    tftypes.NewValue(tftypes.String, "wo_gateway"),
+		"md5_password_wo":         tftypes.NewValue(tftypes.String, "secret1"),
+		"md5_password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+	}
+	state, diags := mock.ApplyResource(t, server, schemas, typeName, tftypes.NewValue(objectType, nil), values)
+	mock.FailOnDiagnostics(t, "Create", diags)
+
+	requests := ms.GetRequestsByMethodAndPath("PUT", "/api/gateways")
+	if len(requests) != 1 || sentMd5Password(requests[0].Body) != "secret1" {
+		t.Fatalf("expected the create request to send secret1, got %+v", requests)
+	}
+	if got := stateAttribute(t, state, "md5_password"); !got.IsNull() {
+		t.Errorf("expected md5_password to stay null after create, got %v", got)
+	}
+
+	// bumping the version sends the new value, which the API echoes back
+	values["md5_password_wo"] = tftypes.NewValue(tftypes.String, "secret2")
+	values["md5_password_wo_version"] = tftypes.NewValue(tftypes.Number, 2)
+	state, diags = mock.ApplyResource(t, server, schemas, typeName, state, values)
+	mock.FailOnDiagnostics(t, "Update", diags)
+
+	requests = ms.GetRequestsByMethodAndPath("PATCH", "/api/gateways")
+	if len(requests) != 1 || sentMd5Password(requests[0].Body) != "secret2" {
+		t.Fatalf("expected the update request to send secret2, got %+v", requests)
+	}
+	if got := stateAttribute(t, state, "md5_password"); !got.IsNull() {
+		t.Errorf("expected md5_password to stay null after update, got %v", got)
+	}
+	for name := range values {
+		if name == "md5_password_wo" {
+			continue
+		}
+		if got := stateAttribute(t, state, name); !got.Equal(values[name]) {
+			t.Errorf("expected %s to be %v, got %v", name, values[name], got)
+		}
+	}
+
+	// the same version does not send the value again
+	values["md5_password_wo"] = tftypes.NewValue(tftypes.String, "secret3")
+	_, diags = mock.ApplyResource(t, server, schemas, typeName, state, values)
+	mock.FailOnDiagnostics(t, "Update", diags)
+	if requests = ms.GetRequestsByMethodAndPath("PATCH", "/api/gateways"); len(requests) != 1 {
+		t.Errorf("expected no request for an unchanged version, got %+v", requests)
+	}
+}
+
+// sentMd5Password returns the md5_password of the gateway in a bulk request body.
+func sentMd5Password(body map[string]interface{}) interface{} {
+	gateways, _ := body["gateway"].(map[string]interface{})
+	gateway, _ := gateways["wo_gateway"].(map[string]interface{})
+	return gateway["md5_password"]
+}
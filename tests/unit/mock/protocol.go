@@ -0,0 +1,211 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/internal/provider"
+)
+
+// ProviderServer returns a protocol server of the real Verity provider along with its
+// schemas, for tests that call the provider directly instead of through Terraform.
+func ProviderServer(t *testing.T) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(provider.New("test")())()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %v", err)
+	}
+	FailOnDiagnostics(t, "GetProviderSchema", schemas.Diagnostics)
+
+	return server, schemas
+}
+
+// ConfigureProvider configures the provider server with the given provider attributes
+// and returns the diagnostics.
+func ConfigureProvider(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, values map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	return ConfigureProviderContext(context.Background(), t, server, schemas, values)
+}
+
+// ConfigureProviderContext is ConfigureProvider with a caller supplied context, e.g. one
+// carrying a test logger from tflogtest.
+func ConfigureProviderContext(ctx context.Context, t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, values map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: ObjectValue(t, schemas.Provider, values),
+	})
+	if err != nil {
+		t.Fatalf("failed to configure provider: %v", err)
+	}
+	return resp.Diagnostics
+}
+
+// ProviderValues returns the provider attributes pointing at the mock server.
+func ProviderValues(serverURL, mode string) map[string]tftypes.Value {
+	return map[string]tftypes.Value{
+		"uri":      tftypes.NewValue(tftypes.String, serverURL),
+		"username": tftypes.NewValue(tftypes.String, "test"),
+		"password": tftypes.NewValue(tftypes.String, "test"),
+		"mode":     tftypes.NewValue(tftypes.String, mode),
+	}
+}
+
+// ObjectValue builds a config value for the schema, leaving unset attributes null.
+func ObjectValue(t *testing.T, s *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	objectType := s.ValueType().(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attributes[name] = v
+		} else {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatalf("failed to build config value: %v", err)
+	}
+	return &dv
+}
+
+// PlanResourceCreate plans the creation of a resource with the given config values.
+func PlanResourceCreate(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, values map[string]tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	t.Helper()
+
+	s, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("resource %s is not registered", typeName)
+	}
+
+	config := ObjectValue(t, s, values)
+	priorState, err := tfprotov6.NewDynamicValue(s.ValueType(), tftypes.NewValue(s.ValueType(), nil))
+	if err != nil {
+		t.Fatalf("failed to build prior state: %v", err)
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &priorState,
+		ProposedNewState: config,
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan %s: %v", typeName, err)
+	}
+	return resp
+}
+
+// ApplyResource plans and applies a change of a resource from priorState, as Terraform does,
+// and returns the new state. priorState is a null value of the schema type for a create, and
+// nil values destroy the resource.
+func ApplyResource(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, priorState tftypes.Value, values map[string]tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	s, ok := schemas.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("resource %s is not registered", typeName)
+	}
+	objectType := s.ValueType()
+
+	prior, err := tfprotov6.NewDynamicValue(objectType, priorState)
+	if err != nil {
+		t.Fatalf("failed to build prior state: %v", err)
+	}
+
+	var config, proposed *tfprotov6.DynamicValue
+	if values == nil {
+		null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+		if err != nil {
+			t.Fatalf("failed to build config value: %v", err)
+		}
+		config, proposed = &null, &null
+	} else {
+		config = ObjectValue(t, s, values)
+		proposed = ObjectValue(t, s, proposedNewState(s, priorState, values))
+	}
+
+	planResp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       &prior,
+		ProposedNewState: proposed,
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan %s: %v", typeName, err)
+	}
+	for _, d := range planResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return priorState, planResp.Diagnostics
+		}
+	}
+
+	applyResp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     &prior,
+		PlannedState:   planResp.PlannedState,
+		Config:         config,
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatalf("failed to apply %s: %v", typeName, err)
+	}
+
+	newState := tftypes.NewValue(objectType, nil)
+	if applyResp.NewState != nil {
+		if newState, err = applyResp.NewState.Unmarshal(objectType); err != nil {
+			t.Fatalf("failed to decode new state of %s: %v", typeName, err)
+		}
+	}
+	return newState, append(planResp.Diagnostics, applyResp.Diagnostics...)
+}
+
+// proposedNewState merges the config values with the prior state the way Terraform does:
+// computed attributes that are not configured keep their prior value and write-only
+// attributes are left out.
+func proposedNewState(s *tfprotov6.Schema, priorState tftypes.Value, values map[string]tftypes.Value) map[string]tftypes.Value {
+	var prior map[string]tftypes.Value
+	if !priorState.IsNull() {
+		if err := priorState.As(&prior); err != nil {
+			prior = nil
+		}
+	}
+
+	proposed := make(map[string]tftypes.Value, len(values))
+	for name, v := range values {
+		proposed[name] = v
+	}
+	for _, attr := range s.Block.Attributes {
+		v, ok := proposed[attr.Name]
+		switch {
+		case attr.WriteOnly:
+			delete(proposed, attr.Name)
+		case attr.Computed && (!ok || v.IsNull()) && prior != nil:
+			proposed[attr.Name] = prior[attr.Name]
+		}
+	}
+	return proposed
+}
+
+// FailOnDiagnostics fails the test if diags contains an error.
+func FailOnDiagnostics(t *testing.T, operation string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", operation, d.Summary, d.Detail)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 type CapturedRequest struct {
@@ -32,12 +33,35 @@ type MockServer struct {
 	postPutEnrichment map[string]map[string]map[string]map[string]interface{}
 	testLogger        testing.TB
 	versionResponse   []byte
+	authCount         int
+	token             string
+	enforceToken      bool
+	responseDelay     time.Duration
+	failures          map[string][]MockFailure
+}
+
+// MockFailure is an error response returned instead of the regular one.
+type MockFailure struct {
+	Status     int
+	Body       string
+	RetryAfter string
 }
 
 func NewMockServer(mode string) *MockServer {
+	return newMockServer(mode, httptest.NewServer)
+}
+
+// NewMockTLSServer is NewMockServer served over HTTPS with a self-signed certificate,
+// available through Server.Certificate().
+func NewMockTLSServer(mode string) *MockServer {
+	return newMockServer(mode, httptest.NewTLSServer)
+}
+
+func newMockServer(mode string, start func(http.Handler) *httptest.Server) *MockServer {
 	ms := &MockServer{
 		getResponses:  make(map[string][]byte),
 		resourceState: make(map[string]map[string]map[string]interface{}),
+		token:         "mock-test-token",
 	}
 
 	datacenter := mode == "datacenter"
@@ -49,7 +73,7 @@ func NewMockServer(mode string) *MockServer {
 		ms.handleRequest(w, r)
 	})
 
-	ms.Server = httptest.NewServer(handler)
+	ms.Server = start(handler)
 	return ms
 }
 
@@ -151,6 +175,64 @@ func (ms *MockServer) SetTestLogger(t testing.TB) {
 	ms.testLogger = t
 }
 
+// ExpireToken invalidates the session token, so the following requests are rejected with
+// 401 until the client authenticates again.
+func (ms *MockServer) ExpireToken() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.authCount = 0
+	ms.token = fmt.Sprintf("mock-test-token-%d", time.Now().UnixNano())
+	ms.enforceToken = true
+}
+
+// RequireToken makes the server reject every request that does not carry token, which
+// is also the token handed out on authentication.
+func (ms *MockServer) RequireToken(token string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.token = token
+	ms.enforceToken = true
+}
+
+// FailRequests makes the next requests to method and path fail with the given responses,
+// one per request, before the server answers normally again.
+func (ms *MockServer) FailRequests(method, path string, failures ...MockFailure) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.failures == nil {
+		ms.failures = make(map[string][]MockFailure)
+	}
+	key := method + " " + path
+	ms.failures[key] = append(ms.failures[key], failures...)
+}
+
+// SetVersionResponse replaces the body returned by the version endpoint.
+func (ms *MockServer) SetVersionResponse(data []byte) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.versionResponse = data
+}
+
+// SetResponseDelay delays every response except authentication by d.
+func (ms *MockServer) SetResponseDelay(d time.Duration) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.responseDelay = d
+}
+
+// AuthCount returns the number of authentications since the server started or the token
+// was last expired.
+func (ms *MockServer) AuthCount() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.authCount
+}
+
+func hasToken(r *http.Request, token string) bool {
+	cookie, err := r.Cookie("ivn_api")
+	return err == nil && cookie.Value == token
+}
+
 func (ms *MockServer) Close() {
 	ms.Server.Close()
 }
@@ -162,16 +244,37 @@ func (ms *MockServer) URL() string {
 func (ms *MockServer) handleRequest(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path == "/api/auth" && r.Method == http.MethodPost {
 		io.ReadAll(r.Body)
+		ms.mu.Lock()
+		ms.authCount++
+		token := ms.token
+		ms.mu.Unlock()
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"token":"mock-test-token"}`))
+		fmt.Fprintf(w, `{"token":%q}`, token)
+		return
+	}
+
+	ms.mu.Lock()
+	rejected := ms.enforceToken && !hasToken(r, ms.token)
+	delay := ms.responseDelay
+	ms.mu.Unlock()
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	if rejected {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Unauthorized"}`))
 		return
 	}
 
 	if r.URL.Path == "/api/version" && r.Method == http.MethodGet {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		w.Write(ms.versionResponse)
+		ms.mu.Lock()
+		versionResponse := ms.versionResponse
+		ms.mu.Unlock()
+		w.Write(versionResponse)
 		return
 	}
 
@@ -195,6 +298,20 @@ func (ms *MockServer) handleRequest(w http.ResponseWriter, r *http.Request) {
 	ms.mu.Lock()
 	ms.requests = append(ms.requests, captured)
 
+	failureKey := r.Method + " " + r.URL.Path
+	if queued := ms.failures[failureKey]; len(queued) > 0 {
+		failure := queued[0]
+		ms.failures[failureKey] = queued[1:]
+		ms.mu.Unlock()
+		if failure.RetryAfter != "" {
+			w.Header().Set("Retry-After", failure.RetryAfter)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure.Status)
+		w.Write([]byte(failure.Body))
+		return
+	}
+
 	if ms.testLogger != nil {
 		switch r.Method {
 		case http.MethodPut, http.MethodPatch:
@@ -0,0 +1,137 @@
+package provider_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/internal/utils"
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func versionServer(t *testing.T, version string) *mock.MockServer {
+	t.Helper()
+
+	ms := modeServer(t, "datacenter")
+	ms.SetVersionResponse([]byte(`{"version":"` + version + `","datacenter":true}`))
+	return ms
+}
+
+func warningSummaries(diags []*tfprotov6.Diagnostic) string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityWarning {
+			summaries = append(summaries, d.Summary+": "+d.Detail)
+		}
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func TestAPIVersion_NewerMinorAcceptedWithWarning(t *testing.T) {
+	ms := versionServer(t, "6.6")
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter"))
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("expected API version 6.6 to be accepted, got: %s", errs)
+	}
+	if warnings := warningSummaries(diags); !strings.Contains(warnings, "Newer API Version") {
+		t.Fatalf("expected a newer API version warning, got: %q", warnings)
+	}
+}
+
+func TestAPIVersion_UnsupportedRejected(t *testing.T) {
+	for _, version := range []string{"6.4", "7.0"} {
+		t.Run(version, func(t *testing.T) {
+			ms := versionServer(t, version)
+
+			server, schemas := mock.ProviderServer(t)
+			errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter")))
+			if !strings.Contains(errs, "API version mismatch") {
+				t.Fatalf("expected API version %s to be rejected, got: %q", version, errs)
+			}
+		})
+	}
+}
+
+func planBadge(t *testing.T, version string, color tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	ms := versionServer(t, version)
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "configure", mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter")))
+
+	schema := schemas.ResourceSchemas["verity_badge"]
+	config := mock.ObjectValue(t, schema, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "badge_1"),
+		"color": color,
+	})
+	priorState, err := tfprotov6.NewDynamicValue(schema.ValueType(), tftypes.NewValue(schema.ValueType(), nil))
+	if err != nil {
+		t.Fatalf("failed to build prior state: %v", err)
+	}
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "verity_badge",
+		PriorState:       &priorState,
+		ProposedNewState: config,
+		Config:           config,
+	})
+	if err != nil {
+		t.Fatalf("failed to plan: %v", err)
+	}
+	return resp.Diagnostics
+}
+
+func TestAPIVersion_FieldOutsideVersionRange(t *testing.T) {
+	utils.ModeFields["badges"]["color"] = utils.FieldModeBoth.Since(6)
+	t.Cleanup(func() { utils.ModeFields["badges"]["color"] = utils.FieldModeBoth })
+
+	if errs := errorSummaries(planBadge(t, "6.5", tftypes.NewValue(tftypes.String, "red"))); !strings.Contains(errs, "Unsupported Attribute") {
+		t.Fatalf("expected color to be rejected on API version 6.5, got: %q", errs)
+	}
+	if errs := errorSummaries(planBadge(t, "6.5", tftypes.NewValue(tftypes.String, nil))); errs != "" {
+		t.Fatalf("expected an unset color to be accepted on API version 6.5, got: %s", errs)
+	}
+}
+
+func TestAPIVersion_FieldRemovedInNewerVersion(t *testing.T) {
+	utils.ModeFields["badges"]["color"] = utils.FieldModeBoth.Until(5)
+	t.Cleanup(func() { utils.ModeFields["badges"]["color"] = utils.FieldModeBoth })
+
+	if errs := errorSummaries(planBadge(t, "6.5", tftypes.NewValue(tftypes.String, "red"))); errs != "" {
+		t.Fatalf("expected color to be accepted on API version 6.5, got: %s", errs)
+	}
+	if errs := errorSummaries(planBadge(t, "6.6", tftypes.NewValue(tftypes.String, "red"))); !strings.Contains(errs, "Unsupported Attribute") {
+		t.Fatalf("expected color to be rejected on API version 6.6, got: %q", errs)
+	}
+}
+
+func TestAPIVersion_BlockOutsideVersionRange(t *testing.T) {
+	utils.ModeFields["badges"]["object_properties"] = utils.FieldModeBoth.Until(4)
+	t.Cleanup(func() { utils.ModeFields["badges"]["object_properties"] = utils.FieldModeBoth })
+
+	objectPropertiesType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"notes": tftypes.String}}
+	blockType := tftypes.List{ElementType: objectPropertiesType}
+	values := map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "badge_1"),
+		"object_properties": tftypes.NewValue(blockType, []tftypes.Value{
+			tftypes.NewValue(objectPropertiesType, map[string]tftypes.Value{"notes": tftypes.NewValue(tftypes.String, "n")}),
+		}),
+	}
+
+	ms := versionServer(t, "6.5")
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "configure", mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter")))
+
+	if errs := errorSummaries(mock.PlanResourceCreate(t, server, schemas, "verity_badge", values).Diagnostics); !strings.Contains(errs, "Unsupported Attribute") {
+		t.Fatalf("expected object_properties to be rejected on API version 6.5, got: %q", errs)
+	}
+
+	values["object_properties"] = tftypes.NewValue(blockType, []tftypes.Value{})
+	if errs := errorSummaries(mock.PlanResourceCreate(t, server, schemas, "verity_badge", values).Diagnostics); errs != "" {
+		t.Fatalf("expected an unset object_properties block to be accepted on API version 6.5, got: %s", errs)
+	}
+}
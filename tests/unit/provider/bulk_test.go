@@ -0,0 +1,88 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var bulkType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"batch_collection_window":  tftypes.String,
+	"max_batch_delay":          tftypes.String,
+	"response_processor_delay": tftypes.String,
+	"debounce_delay":           tftypes.String,
+	"adaptive_debounce":        tftypes.Bool,
+	"max_batch_size":           tftypes.Number,
+	"max_delete_batch_size":    tftypes.Number,
+	"max_parallel_batches":     tftypes.Number,
+	"on_error":                 tftypes.String,
+	"rollback_on_failure":      tftypes.Bool,
+}}
+
+// bulkValue builds a bulk attribute, leaving attributes not in values null.
+func bulkValue(values map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value, len(bulkType.AttributeTypes))
+	for name, attrType := range bulkType.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attributes[name] = v
+		} else {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(bulkType, attributes)
+}
+
+func TestBulk_Accepted(t *testing.T) {
+	ms := transportServer(t)
+
+	errs := configureTransport(t, ms, map[string]tftypes.Value{
+		"bulk": bulkValue(map[string]tftypes.Value{
+			"batch_collection_window":  tftypes.NewValue(tftypes.String, "500ms"),
+			"response_processor_delay": tftypes.NewValue(tftypes.String, "0s"),
+			"debounce_delay":           tftypes.NewValue(tftypes.String, "5s"),
+			"adaptive_debounce":        tftypes.NewValue(tftypes.Bool, true),
+			"max_batch_size":           tftypes.NewValue(tftypes.Number, 200),
+			"max_delete_batch_size":    tftypes.NewValue(tftypes.Number, 50),
+			"max_parallel_batches":     tftypes.NewValue(tftypes.Number, 4),
+			"on_error":                 tftypes.NewValue(tftypes.String, "continue"),
+		}),
+	})
+	if errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+}
+
+func TestBulk_Invalid(t *testing.T) {
+	tests := map[string]map[string]tftypes.Value{
+		"zero batch size":       {"max_batch_size": tftypes.NewValue(tftypes.Number, 0)},
+		"zero debounce delay":   {"debounce_delay": tftypes.NewValue(tftypes.String, "0s")},
+		"malformed duration":    {"max_batch_delay": tftypes.NewValue(tftypes.String, "soon")},
+		"unknown on_error":      {"on_error": tftypes.NewValue(tftypes.String, "ignore")},
+		"zero parallel batches": {"max_parallel_batches": tftypes.NewValue(tftypes.Number, 0)},
+		"rollback with continue": {
+			"on_error":            tftypes.NewValue(tftypes.String, "continue"),
+			"rollback_on_failure": tftypes.NewValue(tftypes.Bool, true),
+		},
+	}
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			ms := transportServer(t)
+
+			errs := configureTransport(t, ms, map[string]tftypes.Value{"bulk": bulkValue(values)})
+			if !strings.Contains(errs, "Invalid Bulk Configuration") {
+				t.Fatalf("expected an invalid bulk configuration error, got: %q", errs)
+			}
+		})
+	}
+}
+
+func TestBulk_EnvironmentFallback(t *testing.T) {
+	ms := transportServer(t)
+	t.Setenv("TF_VAR_bulk_max_delete_batch_size", "many")
+
+	errs := configureTransport(t, ms, nil)
+	if !strings.Contains(errs, "TF_VAR_bulk_max_delete_batch_size must be an integer") {
+		t.Fatalf("expected the environment variable to be read, got: %q", errs)
+	}
+}
@@ -0,0 +1,108 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func TestChangeset_ExcludedPaths(t *testing.T) {
+	ms := transportServer(t)
+	target, err := url.Parse(ms.URL())
+	if err != nil {
+		t.Fatalf("failed to parse mock server URL: %v", err)
+	}
+
+	// the mock server does not capture auth and version requests, so a proxy in front of it
+	// records the changeset of every request
+	var mu sync.Mutex
+	changesets := make(map[string][]string)
+	reverseProxy := httputil.NewSingleHostReverseProxy(target)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		changesets[r.URL.Path] = append(changesets[r.URL.Path], r.URL.Query().Get("changeset_name"))
+		mu.Unlock()
+		reverseProxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	values := mock.ProviderValues(proxy.URL, "datacenter")
+	values["changeset"] = tftypes.NewValue(tftypes.String, "cs1")
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, values))
+
+	mock.FailOnDiagnostics(t, "Read", readDataSourceDiagnostics(t, server, schemas, "verity_gateways"))
+	// only the request matters, not whether the mock server knows the switchpoint
+	if _, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: "verity_switchpoint_current_config",
+		Config: mock.ObjectValue(t, schemas.DataSourceSchemas["verity_switchpoint_current_config"], map[string]tftypes.Value{
+			"switchpoint_name": tftypes.NewValue(tftypes.String, "sw1"),
+		}),
+	}); err != nil {
+		t.Fatalf("failed to read verity_switchpoint_current_config: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, want := range map[string]string{
+		"/api/auth":                       "",
+		"/api/version":                    "",
+		"/api/switchpoints/currentconfig": "",
+		"/api/gateways":                   "cs1",
+	} {
+		if len(changesets[path]) == 0 {
+			t.Errorf("expected a request to %s", path)
+		}
+		for _, got := range changesets[path] {
+			if got != want {
+				t.Errorf("expected %s to have changeset_name %q, got %q", path, want, got)
+			}
+		}
+	}
+}
+
+func TestChangeset_ResourceRequests(t *testing.T) {
+	ms := transportServer(t)
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["changeset"] = tftypes.NewValue(tftypes.String, "cs1")
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, values))
+
+	tenantType := schemas.ResourceSchemas["verity_tenant"].ValueType()
+	state, diags := mock.ApplyResource(t, server, schemas, "verity_tenant", tftypes.NewValue(tenantType, nil), map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "changeset_tenant"),
+	})
+	mock.FailOnDiagnostics(t, "Create", diags)
+
+	state, diags = mock.ApplyResource(t, server, schemas, "verity_tenant", state, map[string]tftypes.Value{
+		"name":   tftypes.NewValue(tftypes.String, "changeset_tenant"),
+		"enable": tftypes.NewValue(tftypes.Bool, true),
+	})
+	mock.FailOnDiagnostics(t, "Update", diags)
+
+	_, diags = mock.ApplyResource(t, server, schemas, "verity_tenant", state, nil)
+	mock.FailOnDiagnostics(t, "Delete", diags)
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		requests := ms.GetRequestsByMethodAndPath(method, "/api/tenants")
+		if len(requests) == 0 {
+			t.Errorf("expected a %s request", method)
+		}
+		for _, req := range requests {
+			if got := req.QueryParams["changeset_name"]; len(got) != 1 || got[0] != "cs1" {
+				t.Errorf("expected %s %s to be scoped to cs1, got %v", method, req.Path, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package provider_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+var execType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"command": tftypes.String,
+	"args":    tftypes.List{ElementType: tftypes.String},
+	"env":     tftypes.Map{ElementType: tftypes.String},
+}}
+
+func credentialServer(t *testing.T, token string) *mock.MockServer {
+	t.Helper()
+	t.Setenv("VERITY_TF_WORKDIR", t.TempDir())
+
+	ms := mock.NewMockServer("datacenter")
+	t.Cleanup(ms.Close)
+	ms.RequireToken(token)
+	return ms
+}
+
+// tokenValues returns the provider attributes for the mock server without username and password.
+func tokenValues(ms *mock.MockServer, extra map[string]tftypes.Value) map[string]tftypes.Value {
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	delete(values, "username")
+	delete(values, "password")
+	for name, v := range extra {
+		values[name] = v
+	}
+	return values
+}
+
+func execValue(command string, args []string, env map[string]string) tftypes.Value {
+	argValues := make([]tftypes.Value, 0, len(args))
+	for _, arg := range args {
+		argValues = append(argValues, tftypes.NewValue(tftypes.String, arg))
+	}
+	envValues := make(map[string]tftypes.Value, len(env))
+	for name, value := range env {
+		envValues[name] = tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(execType, map[string]tftypes.Value{
+		"command": tftypes.NewValue(tftypes.String, command),
+		"args":    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, argValues),
+		"env":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, envValues),
+	})
+}
+
+func writeHelper(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper.sh")
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatalf("failed to write credential helper: %v", err)
+	}
+	return path
+}
+
+func TestCredentials_APIToken(t *testing.T) {
+	ms := credentialServer(t, "pre-issued-token")
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "pre-issued-token"),
+	}))
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if got := ms.AuthCount(); got != 0 {
+		t.Fatalf("expected no password authentication with api_token, got %d", got)
+	}
+}
+
+func TestCredentials_RejectedAPITokenWithoutPassword(t *testing.T) {
+	ms := credentialServer(t, "current-token")
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "revoked-token"),
+	}))
+	if errorSummaries(diags) == "" {
+		t.Fatal("expected configuring with a rejected api_token to fail")
+	}
+	if got := ms.AuthCount(); got != 0 {
+		t.Fatalf("expected no password authentication without a password, got %d", got)
+	}
+}
+
+func TestCredentials_RejectedAPITokenFallsBackToPassword(t *testing.T) {
+	ms := credentialServer(t, "current-token")
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["api_token"] = tftypes.NewValue(tftypes.String, "revoked-token")
+
+	server, schemas := mock.ProviderServer(t)
+	if errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, values)); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if got := ms.AuthCount(); got != 1 {
+		t.Fatalf("expected 1 password authentication after api_token was rejected, got %d", got)
+	}
+}
+
+func TestCredentials_APITokenSurvivesForbidden(t *testing.T) {
+	ms := credentialServer(t, "pre-issued-token")
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "pre-issued-token"),
+	}))
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+
+	// a 403 does not mean the token was revoked, so it is still used for later requests
+	ms.FailRequests("GET", "/api/tenants", mock.MockFailure{Status: http.StatusForbidden, Body: `{"error":"Forbidden"}`})
+	tenantType := schemas.ResourceSchemas["verity_tenant"].ValueType()
+	state, diags := mock.ApplyResource(t, server, schemas, "verity_tenant", tftypes.NewValue(tenantType, nil), map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "forbidden_tenant"),
+	})
+	mock.FailOnDiagnostics(t, "Create", diags)
+
+	_, diags = mock.ApplyResource(t, server, schemas, "verity_tenant", state, nil)
+	mock.FailOnDiagnostics(t, "Delete", diags)
+	if got := ms.AuthCount(); got != 0 {
+		t.Fatalf("expected no password authentication with api_token, got %d", got)
+	}
+}
+
+func TestCredentials_ExecHelper(t *testing.T) {
+	ms := credentialServer(t, "helper-token")
+	helper := writeHelper(t, `#!/bin/sh
+printf '{"token": "%s", "expires_in": 3600}' "$VERITY_TEST_TOKEN"
+`)
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"exec": execValue("/bin/sh", []string{helper}, map[string]string{"VERITY_TEST_TOKEN": "helper-token"}),
+	}))
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if got := ms.AuthCount(); got != 0 {
+		t.Fatalf("expected no password authentication with exec, got %d", got)
+	}
+}
+
+func TestCredentials_ExecHelperFailure(t *testing.T) {
+	ms := credentialServer(t, "helper-token")
+	helper := writeHelper(t, `#!/bin/sh
+echo "vault login required" >&2
+exit 1
+`)
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"exec": execValue("/bin/sh", []string{helper}, nil),
+	}))
+	if errs := errorSummaries(diags); !strings.Contains(errs, "vault login required") {
+		t.Fatalf("expected the helper's stderr in the error, got: %q", errs)
+	}
+}
+
+func TestCredentials_APITokenAndExecConflict(t *testing.T) {
+	ms := credentialServer(t, "token")
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, tokenValues(ms, map[string]tftypes.Value{
+		"api_token": tftypes.NewValue(tftypes.String, "token"),
+		"exec":      execValue("/bin/true", nil, nil),
+	}))
+	if errs := errorSummaries(diags); !strings.Contains(errs, "Conflicting Credentials") {
+		t.Fatalf("expected a conflict error, got: %q", errs)
+	}
+}
@@ -0,0 +1,133 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+// objectPropertiesBlock builds an object_properties block of typeName with the given fields.
+func objectPropertiesBlock(t *testing.T, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, fields map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	listType := schemas.ResourceSchemas[typeName].ValueType().(tftypes.Object).AttributeTypes["object_properties"].(tftypes.List)
+	objectType := listType.ElementType.(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := fields[name]; ok {
+			attributes[name] = v
+		} else {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(listType, []tftypes.Value{tftypes.NewValue(objectType, attributes)})
+}
+
+// plannedObjectProperty returns a string field of the planned object_properties block.
+func plannedObjectProperty(t *testing.T, schemas *tfprotov6.GetProviderSchemaResponse, typeName string, resp *tfprotov6.PlanResourceChangeResponse, field string) tftypes.Value {
+	t.Helper()
+
+	planned, err := resp.PlannedState.Unmarshal(schemas.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatalf("failed to decode planned state: %v", err)
+	}
+	value, _, err := tftypes.WalkAttributePath(planned, tftypes.NewAttributePath().
+		WithAttributeName("object_properties").WithElementKeyInt(0).WithAttributeName(field))
+	if err != nil {
+		t.Fatalf("planned state has no object_properties.%s: %v", field, err)
+	}
+	return value.(tftypes.Value)
+}
+
+func configureDefaultObjectProperties(t *testing.T, defaults map[string]string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	ms := transportServer(t)
+	elements := make(map[string]tftypes.Value, len(defaults))
+	for name, value := range defaults {
+		elements[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["default_object_properties"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, values))
+	return server, schemas
+}
+
+func TestDefaultObjectProperties_MergedIntoPlan(t *testing.T) {
+	server, schemas := configureDefaultObjectProperties(t, map[string]string{
+		"group": "netops",
+		"notes": "managed by terraform",
+	})
+
+	resp := mock.PlanResourceCreate(t, server, schemas, "verity_gateway_profile", map[string]tftypes.Value{
+		"name":              tftypes.NewValue(tftypes.String, "gateway_profile_1"),
+		"object_properties": objectPropertiesBlock(t, schemas, "verity_gateway_profile", nil),
+	})
+	mock.FailOnDiagnostics(t, "PlanResourceChange", resp.Diagnostics)
+
+	if got := plannedObjectProperty(t, schemas, "verity_gateway_profile", resp, "group"); !got.Equal(tftypes.NewValue(tftypes.String, "netops")) {
+		t.Fatalf("expected the default group to be planned, got %s", got)
+	}
+}
+
+func TestDefaultObjectProperties_ResourceValueWins(t *testing.T) {
+	server, schemas := configureDefaultObjectProperties(t, map[string]string{
+		"group": "netops",
+		"notes": "managed by terraform",
+	})
+
+	// badges have notes but no group, which is skipped
+	resp := mock.PlanResourceCreate(t, server, schemas, "verity_badge", map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "badge_1"),
+		"object_properties": objectPropertiesBlock(t, schemas, "verity_badge", map[string]tftypes.Value{
+			"notes": tftypes.NewValue(tftypes.String, "lab badge"),
+		}),
+	})
+	mock.FailOnDiagnostics(t, "PlanResourceChange", resp.Diagnostics)
+
+	if got := plannedObjectProperty(t, schemas, "verity_badge", resp, "notes"); !got.Equal(tftypes.NewValue(tftypes.String, "lab badge")) {
+		t.Fatalf("expected the configured notes to be planned, got %s", got)
+	}
+}
+
+func TestDefaultObjectProperties_MissingBlockWarns(t *testing.T) {
+	server, schemas := configureDefaultObjectProperties(t, map[string]string{
+		"group": "netops",
+	})
+
+	listType := schemas.ResourceSchemas["verity_gateway_profile"].ValueType().(tftypes.Object).AttributeTypes["object_properties"]
+	resp := mock.PlanResourceCreate(t, server, schemas, "verity_gateway_profile", map[string]tftypes.Value{
+		"name":              tftypes.NewValue(tftypes.String, "gateway_profile_1"),
+		"object_properties": tftypes.NewValue(listType, []tftypes.Value{}),
+	})
+	mock.FailOnDiagnostics(t, "PlanResourceChange", resp.Diagnostics)
+
+	if warnings := warningSummaries(resp.Diagnostics); !strings.Contains(warnings, "Default Object Properties Not Applied") ||
+		!strings.Contains(warnings, "for group") {
+		t.Fatalf("expected a warning that the group default was skipped, got: %q", warnings)
+	}
+}
+
+func TestDefaultObjectProperties_UnknownFieldRejected(t *testing.T) {
+	ms := transportServer(t)
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["default_object_properties"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"gruop": tftypes.NewValue(tftypes.String, "netops"),
+		"notes": tftypes.NewValue(tftypes.String, "managed by terraform"),
+	})
+
+	server, schemas := mock.ProviderServer(t)
+	errors := errorSummaries(mock.ConfigureProvider(t, server, schemas, values))
+	if !strings.Contains(errors, "Invalid Default Object Properties") || !strings.Contains(errors, `"gruop"`) ||
+		strings.Contains(errors, `"notes"`) {
+		t.Fatalf("expected only gruop to be rejected, got: %q", errors)
+	}
+}
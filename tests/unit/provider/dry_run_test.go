@@ -0,0 +1,38 @@
+package provider_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func TestDryRun_Warning(t *testing.T) {
+	ms := transportServer(t)
+	file := filepath.Join(t.TempDir(), "requests.jsonl")
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["dry_run"] = tftypes.NewValue(tftypes.Bool, true)
+	values["dry_run_file"] = tftypes.NewValue(tftypes.String, file)
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, values)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", diags)
+
+	if warnings := warningSummaries(diags); !strings.Contains(warnings, "Dry Run") || !strings.Contains(warnings, file) {
+		t.Fatalf("expected a dry run warning naming %s, got: %q", file, warnings)
+	}
+}
+
+func TestDryRun_EnvironmentFallback(t *testing.T) {
+	ms := transportServer(t)
+	t.Setenv("VERITY_DRY_RUN", "maybe")
+
+	errs := configureTransport(t, ms, nil)
+	if !strings.Contains(errs, "VERITY_DRY_RUN must be a boolean") {
+		t.Fatalf("expected the environment variable to be read, got: %q", errs)
+	}
+}
@@ -0,0 +1,84 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func failoverURIs(uris ...string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(uris))
+	for _, uri := range uris {
+		values = append(values, tftypes.NewValue(tftypes.String, uri))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+}
+
+// stoppedServerURL returns the URL of a controller that refuses connections.
+func stoppedServerURL(t *testing.T) string {
+	t.Helper()
+
+	ms := mock.NewMockServer("datacenter")
+	ms.Close()
+	return ms.URL()
+}
+
+func TestFailover_UnreachablePrimarySkipped(t *testing.T) {
+	standby := transportServer(t)
+
+	values := mock.ProviderValues(stoppedServerURL(t), "datacenter")
+	values["failover_uris"] = failoverURIs(standby.URL())
+
+	server, schemas := mock.ProviderServer(t)
+	if errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, values)); errs != "" {
+		t.Fatalf("expected the provider to use the standby controller, got: %s", errs)
+	}
+	if got := standby.AuthCount(); got != 1 {
+		t.Fatalf("expected 1 authentication on the standby controller, got %d", got)
+	}
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_route_maps")); errs != "" {
+		t.Fatalf("expected the read to succeed on the standby controller, got: %s", errs)
+	}
+}
+
+func TestFailover_MidRunAuthenticatesOnStandby(t *testing.T) {
+	primary := transportServer(t)
+	primary.RequireToken("primary-token")
+	standby := transportServer(t)
+	standby.RequireToken("standby-token")
+
+	values := mock.ProviderValues(primary.URL(), "datacenter")
+	values["failover_uris"] = failoverURIs(standby.URL())
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, values))
+	if got := standby.AuthCount(); got != 0 {
+		t.Fatalf("expected no authentication on the standby controller while the primary is healthy, got %d", got)
+	}
+
+	primary.Close()
+
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_route_maps")); errs != "" {
+		t.Fatalf("expected the read to fail over to the standby controller, got: %s", errs)
+	}
+	if got := standby.AuthCount(); got != 1 {
+		t.Fatalf("expected 1 authentication on the standby controller after failing over, got %d", got)
+	}
+	if got := len(standby.GetRequestsByMethod("GET")); got == 0 {
+		t.Fatal("expected the read to be sent to the standby controller")
+	}
+}
+
+func TestFailover_InvalidURI(t *testing.T) {
+	ms := transportServer(t)
+
+	errs := configureTransport(t, ms, map[string]tftypes.Value{
+		"failover_uris": failoverURIs("ftp://standby.example.com"),
+	})
+	if !strings.Contains(errs, "Invalid Failover URIs") {
+		t.Fatalf("expected an invalid failover URI error, got: %q", errs)
+	}
+}
@@ -0,0 +1,78 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+const (
+	loggedPassword  = "cleartext-pass-1234"
+	loggedSecret    = "encrypted-secret-5678"
+	loggedCommunity = "snmp-community-9012"
+)
+
+func configureWithLog(t *testing.T, httpLog string) (*bytes.Buffer, *mock.MockServer) {
+	t.Helper()
+	t.Setenv("VERITY_HTTP_LOG", httpLog)
+
+	ms := transportServer(t)
+	ms.SetGetResponse("/api/gateways", []byte(`{"gateway":{"gw1":{"name":"gw1","enable":true,"md5_password_encrypted":"`+loggedSecret+`","snmp_community_string":"`+loggedCommunity+`"}}}`))
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["password"] = tftypes.NewValue(tftypes.String, loggedPassword)
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProviderContext(ctx, t, server, schemas, values))
+
+	diags := readDataSourceDiagnostics(t, server, schemas, "verity_gateways")
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	return &logs, ms
+}
+
+func TestHTTPLog_BodiesAreRedacted(t *testing.T) {
+	logs, _ := configureWithLog(t, "body")
+	output := logs.String()
+
+	for _, want := range []string{"/api/auth", "/api/version", "/api/gateways", "redacted", `.http"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected the log to contain %q", want)
+		}
+	}
+	for _, secret := range []string{loggedPassword, loggedSecret, loggedCommunity, "mock-test-token"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("log contains %q in clear text", secret)
+		}
+	}
+}
+
+func TestHTTPLog_Basic(t *testing.T) {
+	logs, _ := configureWithLog(t, "basic")
+	output := logs.String()
+
+	if !strings.Contains(output, "/api/gateways") {
+		t.Error("expected the log to contain the requested URL")
+	}
+	if strings.Contains(output, "request_body") || strings.Contains(output, "response_body") {
+		t.Error("expected no bodies in the basic log")
+	}
+}
+
+func TestHTTPLog_Off(t *testing.T) {
+	logs, _ := configureWithLog(t, "")
+
+	if strings.Contains(logs.String(), `.http"`) {
+		t.Error("expected no wire log without VERITY_HTTP_LOG")
+	}
+}
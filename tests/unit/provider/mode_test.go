@@ -0,0 +1,91 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+// configureMode configures the provider against ms with mode set to configuredMode, or left
+// unset when configuredMode is empty, and returns the configuration errors.
+func configureMode(t *testing.T, ms *mock.MockServer, configuredMode string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse, string) {
+	t.Helper()
+	t.Setenv("TF_VAR_mode", "")
+
+	values := mock.ProviderValues(ms.URL(), configuredMode)
+	if configuredMode == "" {
+		delete(values, "mode")
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, values))
+	return server, schemas, errs
+}
+
+func modeServer(t *testing.T, serverMode string) *mock.MockServer {
+	t.Helper()
+
+	ms := mock.NewMockServer(serverMode)
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir(serverMode)); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+	return ms
+}
+
+func TestMode_Detected(t *testing.T) {
+	for _, configuredMode := range []string{"", "auto"} {
+		for _, serverMode := range []string{"datacenter", "campus"} {
+			t.Run(serverMode+"/"+configuredMode, func(t *testing.T) {
+				server, schemas, errs := configureMode(t, modeServer(t, serverMode), configuredMode)
+				if errs != "" {
+					t.Fatalf("unexpected errors: %s", errs)
+				}
+
+				// tenants only exist in datacenter mode
+				errs = errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_tenants"))
+				if serverMode == "datacenter" && errs != "" {
+					t.Fatalf("expected tenants to be readable in the detected datacenter mode, got: %s", errs)
+				}
+				if serverMode == "campus" && !strings.Contains(errs, "not available in campus mode") {
+					t.Fatalf("expected tenants to be unavailable in the detected campus mode, got: %q", errs)
+				}
+			})
+		}
+	}
+}
+
+func TestMode_MismatchStillFails(t *testing.T) {
+	_, _, errs := configureMode(t, modeServer(t, "campus"), "datacenter")
+	if !strings.Contains(errs, "Mode mismatch") {
+		t.Fatalf("expected a mode mismatch error, got: %q", errs)
+	}
+}
+
+func TestMode_UndetectableWithoutDatacenterField(t *testing.T) {
+	ms := modeServer(t, "datacenter")
+	ms.SetVersionResponse([]byte(`{"version":"6.5"}`))
+
+	if _, _, errs := configureMode(t, ms, ""); !strings.Contains(errs, "cannot be detected") {
+		t.Fatalf("expected a detection error, got: %q", errs)
+	}
+	if _, _, errs := configureMode(t, ms, "campus"); errs != "" {
+		t.Fatalf("expected an explicit mode to work without the datacenter field, got: %s", errs)
+	}
+}
+
+func TestMode_Invalid(t *testing.T) {
+	ms := modeServer(t, "datacenter")
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["mode"] = tftypes.NewValue(tftypes.String, "branch")
+
+	server, schemas := mock.ProviderServer(t)
+	if errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, values)); !strings.Contains(errs, "Invalid Mode") {
+		t.Fatalf("expected an invalid mode error, got: %q", errs)
+	}
+}
@@ -0,0 +1,60 @@
+package provider_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/internal/bulkops"
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func TestOperationJournal_InterruptedBatchWarning(t *testing.T) {
+	ms := transportServer(t)
+	dir := t.TempDir()
+	t.Setenv("VERITY_TF_WORKDIR", dir)
+
+	path := filepath.Join(dir, bulkops.OperationJournalFile(ms.URL(), ""))
+	// the journal of another controller in the same working directory is left alone
+	otherPath := filepath.Join(dir, bulkops.OperationJournalFile("https://other.example", ""))
+	journal := `{"id":"1","time":"2026-01-02T03:04:05Z","resource_type":"tenant","method":"PUT","names":["tenant_missing","tenant_test1"]}` + "\n"
+	for _, p := range []string{path, otherPath} {
+		if err := os.WriteFile(p, []byte(journal), 0o600); err != nil {
+			t.Fatalf("failed to write journal: %v", err)
+		}
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	diags := mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter"))
+	mock.FailOnDiagnostics(t, "ConfigureProvider", diags)
+
+	warnings := warningSummaries(diags)
+	if !strings.Contains(warnings, "Possibly Half-Applied Bulk Operations") ||
+		!strings.Contains(warnings, "created: tenant_test1") || !strings.Contains(warnings, "not created: tenant_missing") {
+		t.Fatalf("expected a warning listing the interrupted tenant PUT, got: %q", warnings)
+	}
+	// configuring the provider for a plan must not lose the batches before they were applied
+	if data, err := os.ReadFile(path); err != nil || string(data) != journal {
+		t.Fatalf("expected configuring the provider to keep the journal, got %q, %v", data, err)
+	}
+
+	tenantType := schemas.ResourceSchemas["verity_tenant"].ValueType()
+	_, diags = mock.ApplyResource(t, server, schemas, "verity_tenant", tftypes.NewValue(tenantType, nil), map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "journal_tenant"),
+	})
+	mock.FailOnDiagnostics(t, "Create", diags)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if strings.Contains(string(data), "tenant_missing") || !strings.Contains(string(data), "journal_tenant") {
+		t.Fatalf("expected the apply to start a new journal, got %q", data)
+	}
+	if other, err := os.ReadFile(otherPath); err != nil || string(other) != journal {
+		t.Fatalf("expected the journal of the other controller to be kept, got %q, %v", other, err)
+	}
+}
@@ -0,0 +1,91 @@
+package provider_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+var reauthDataSources = []string{
+	"verity_route_maps",
+	"verity_ipv4_lists",
+	"verity_sflow_collectors",
+	"verity_badges",
+}
+
+func readDataSourceDiagnostics(t *testing.T, server tfprotov6.ProviderServer, schemas *tfprotov6.GetProviderSchemaResponse, typeName string) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	s, ok := schemas.DataSourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("data source %s is not registered", typeName)
+	}
+
+	resp, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   mock.ObjectValue(t, s, nil),
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", typeName, err)
+	}
+	return resp.Diagnostics
+}
+
+func configuredReauthServer(t *testing.T) (*mock.MockServer, tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	t.Setenv("VERITY_TF_WORKDIR", t.TempDir())
+
+	ms := mock.NewMockServer("datacenter")
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir("datacenter")); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, mock.ProviderValues(ms.URL(), "datacenter")))
+	return ms, server, schemas
+}
+
+func TestReauth_ExpiredTokenIsReplaced(t *testing.T) {
+	ms, server, schemas := configuredReauthServer(t)
+
+	ms.ExpireToken()
+
+	diags := readDataSourceDiagnostics(t, server, schemas, "verity_route_maps")
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("expected the read to succeed after authenticating again, got: %s", errs)
+	}
+	if got := ms.AuthCount(); got != 1 {
+		t.Fatalf("expected 1 authentication after the token expired, got %d", got)
+	}
+}
+
+func TestReauth_ConcurrentRejectionsAuthenticateOnce(t *testing.T) {
+	ms, server, schemas := configuredReauthServer(t)
+
+	ms.ExpireToken()
+
+	var wg sync.WaitGroup
+	results := make([][]*tfprotov6.Diagnostic, len(reauthDataSources))
+	for i, typeName := range reauthDataSources {
+		wg.Add(1)
+		go func(i int, typeName string) {
+			defer wg.Done()
+			results[i] = readDataSourceDiagnostics(t, server, schemas, typeName)
+		}(i, typeName)
+	}
+	wg.Wait()
+
+	for i, diags := range results {
+		if errs := errorSummaries(diags); errs != "" {
+			t.Errorf("%s: %s", reauthDataSources[i], errs)
+		}
+	}
+	if got := ms.AuthCount(); got != 1 {
+		t.Fatalf("expected concurrent rejections to share 1 authentication, got %d", got)
+	}
+}
@@ -0,0 +1,197 @@
+package provider_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+var retryType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"max_retries":   tftypes.Number,
+	"initial_delay": tftypes.String,
+	"max_delay":     tftypes.String,
+}}
+
+func retryValue(maxRetries int, initialDelay, maxDelay string) tftypes.Value {
+	return tftypes.NewValue(retryType, map[string]tftypes.Value{
+		"max_retries":   tftypes.NewValue(tftypes.Number, maxRetries),
+		"initial_delay": tftypes.NewValue(tftypes.String, initialDelay),
+		"max_delay":     tftypes.NewValue(tftypes.String, maxDelay),
+	})
+}
+
+func configureRetry(t *testing.T, ms *mock.MockServer, retry tftypes.Value) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	values["retry"] = retry
+
+	server, schemas := mock.ProviderServer(t)
+	mock.FailOnDiagnostics(t, "ConfigureProvider", mock.ConfigureProvider(t, server, schemas, values))
+	return server, schemas
+}
+
+func gatewayReads(ms *mock.MockServer) int {
+	return len(ms.GetRequestsByMethodAndPath("GET", "/api/gateways"))
+}
+
+func TestRetry_UnavailableIsRetried(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "10ms"))
+
+	ms.FailRequests("GET", "/api/gateways",
+		mock.MockFailure{Status: 503, Body: `{"error":"Service Unavailable"}`},
+		mock.MockFailure{Status: 502, Body: `{"error":"Bad Gateway"}`},
+		mock.MockFailure{Status: 500, Body: `{"error":"Internal Server Error"}`},
+	)
+
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if got := gatewayReads(ms); got != 4 {
+		t.Fatalf("expected 4 attempts, got %d", got)
+	}
+}
+
+func TestRetry_SystemBeingModifiedIsRetried(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "10ms"))
+
+	ms.FailRequests("GET", "/api/gateways", mock.MockFailure{
+		Status: 400,
+		Body:   `{"payload":"The system is currently being modified, please try again later"}`,
+	})
+
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if got := gatewayReads(ms); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetry_RetryAfterIsHonored(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "5s"))
+
+	ms.FailRequests("GET", "/api/gateways", mock.MockFailure{
+		Status:     429,
+		Body:       `{"error":"Too Many Requests"}`,
+		RetryAfter: "1",
+	})
+
+	start := time.Now()
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected the retry to wait for Retry-After, it was sent after %v", elapsed)
+	}
+}
+
+func TestRetry_RetryAfterIsCappedAtMaxDelay(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "10ms"))
+
+	ms.FailRequests("GET", "/api/gateways", mock.MockFailure{
+		Status:     503,
+		Body:       `{"error":"Service Unavailable"}`,
+		RetryAfter: "3600",
+	})
+
+	start := time.Now()
+	if errs := errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected Retry-After to be capped at max_delay, the retry was sent after %v", elapsed)
+	}
+}
+
+func TestRetry_OtherErrorsAreNotRetried(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "10ms"))
+
+	ms.FailRequests("GET", "/api/gateways", mock.MockFailure{Status: 400, Body: `{"error":"invalid value"}`})
+
+	if errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")) == "" {
+		t.Fatal("expected the read to fail")
+	}
+	if got := gatewayReads(ms); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	ms := transportServer(t)
+	server, schemas := configureRetry(t, ms, retryValue(2, "1ms", "10ms"))
+
+	unavailable := mock.MockFailure{Status: 503, Body: `{"error":"Service Unavailable"}`}
+	ms.FailRequests("GET", "/api/gateways", unavailable, unavailable, unavailable, unavailable)
+
+	if errorSummaries(readDataSourceDiagnostics(t, server, schemas, "verity_gateways")) == "" {
+		t.Fatal("expected the read to fail")
+	}
+	if got := gatewayReads(ms); got != 3 {
+		t.Fatalf("expected 1 attempt and 2 retries, got %d", got)
+	}
+}
+
+func TestRetry_InvalidConfiguration(t *testing.T) {
+	tests := map[string]tftypes.Value{
+		"initial delay above max delay": retryValue(3, "1m", "10s"),
+		"too many retries":              retryValue(1000, "1ms", "10ms"),
+	}
+	for name, retry := range tests {
+		t.Run(name, func(t *testing.T) {
+			ms := transportServer(t)
+
+			values := mock.ProviderValues(ms.URL(), "datacenter")
+			values["retry"] = retry
+
+			server, schemas := mock.ProviderServer(t)
+			if errs := errorSummaries(mock.ConfigureProvider(t, server, schemas, values)); !strings.Contains(errs, "Invalid Retry Configuration") {
+				t.Fatalf("expected a retry configuration error, got: %q", errs)
+			}
+		})
+	}
+}
+
+func TestRetry_PatchOnlyRetriedWhenRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		failure  mock.MockFailure
+		attempts int
+	}{
+		{"server error", mock.MockFailure{Status: 503, Body: `{"error":"Service Unavailable"}`}, 1},
+		{"rate limited", mock.MockFailure{Status: 429, Body: `{"error":"Too Many Requests"}`}, 2},
+		{"system being modified", mock.MockFailure{Status: 400, Body: `{"payload":"The system is currently being modified, please try again later"}`}, 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ms := transportServer(t)
+			server, schemas := configureRetry(t, ms, retryValue(3, "1ms", "10ms"))
+
+			tenantType := schemas.ResourceSchemas["verity_tenant"].ValueType()
+			state, diags := mock.ApplyResource(t, server, schemas, "verity_tenant", tftypes.NewValue(tenantType, nil), map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "retry_tenant"),
+			})
+			mock.FailOnDiagnostics(t, "Create", diags)
+
+			ms.FailRequests("PATCH", "/api/tenants", tc.failure)
+			mock.ApplyResource(t, server, schemas, "verity_tenant", state, map[string]tftypes.Value{
+				"name":   tftypes.NewValue(tftypes.String, "retry_tenant"),
+				"enable": tftypes.NewValue(tftypes.Bool, true),
+			})
+
+			if got := len(ms.GetRequestsByMethodAndPath("PATCH", "/api/tenants")); got != tc.attempts {
+				t.Fatalf("expected %d PATCH attempts, got %d", tc.attempts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package provider_test
+
+import (
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+func tlsServer(t *testing.T) *mock.MockServer {
+	t.Helper()
+
+	ms := mock.NewMockTLSServer("datacenter")
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir("datacenter")); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+	return ms
+}
+
+func configureTLS(t *testing.T, ms *mock.MockServer, extra map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	for name, v := range extra {
+		values[name] = v
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	return mock.ConfigureProvider(t, server, schemas, values)
+}
+
+func errorSummaries(diags []*tfprotov6.Diagnostic) string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			summaries = append(summaries, d.Summary+": "+d.Detail)
+		}
+	}
+	return strings.Join(summaries, "; ")
+}
+
+func TestTLS_UntrustedCertificateFails(t *testing.T) {
+	ms := tlsServer(t)
+
+	diags := configureTLS(t, ms, nil)
+	if errorSummaries(diags) == "" {
+		t.Fatal("expected configuring against a self-signed certificate to fail without ca_cert_pem")
+	}
+}
+
+func TestTLS_CACertPEM(t *testing.T) {
+	ms := tlsServer(t)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ms.Server.Certificate().Raw})
+
+	diags := configureTLS(t, ms, map[string]tftypes.Value{
+		"ca_cert_pem": tftypes.NewValue(tftypes.String, string(caPEM)),
+	})
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+}
+
+func TestTLS_InsecureSkipVerify(t *testing.T) {
+	ms := tlsServer(t)
+
+	diags := configureTLS(t, ms, map[string]tftypes.Value{
+		"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if errs := errorSummaries(diags); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+}
+
+func TestTLS_ClientCertWithoutKey(t *testing.T) {
+	ms := tlsServer(t)
+
+	diags := configureTLS(t, ms, map[string]tftypes.Value{
+		"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+		"client_cert":          tftypes.NewValue(tftypes.String, "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"),
+	})
+	if errs := errorSummaries(diags); !strings.Contains(errs, "client_cert and client_key must be set together") {
+		t.Fatalf("expected client_cert/client_key error, got: %q", errs)
+	}
+}
@@ -0,0 +1,147 @@
+package provider_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-verity/tests/unit/mock"
+)
+
+// forwardProxy is a minimal HTTP forward proxy that records the hosts it was asked to reach.
+type forwardProxy struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (p *forwardProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.hosts = append(p.hosts, r.URL.Host)
+	p.mu.Unlock()
+
+	outbound := r.Clone(r.Context())
+	outbound.RequestURI = ""
+	resp, err := http.DefaultTransport.RoundTrip(outbound)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for name, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+func (p *forwardProxy) requestCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.hosts)
+}
+
+func transportServer(t *testing.T) *mock.MockServer {
+	t.Helper()
+	t.Setenv("VERITY_TF_WORKDIR", t.TempDir())
+
+	ms := mock.NewMockServer("datacenter")
+	t.Cleanup(ms.Close)
+	if err := ms.LoadResponsesFromDir(mock.ResponsesDir("datacenter")); err != nil {
+		t.Fatalf("failed to load responses: %v", err)
+	}
+	return ms
+}
+
+func configureTransport(t *testing.T, ms *mock.MockServer, extra map[string]tftypes.Value) string {
+	t.Helper()
+
+	values := mock.ProviderValues(ms.URL(), "datacenter")
+	for name, v := range extra {
+		values[name] = v
+	}
+
+	server, schemas := mock.ProviderServer(t)
+	return errorSummaries(mock.ConfigureProvider(t, server, schemas, values))
+}
+
+func TestTransport_RequestTimeout(t *testing.T) {
+	ms := transportServer(t)
+	ms.SetResponseDelay(500 * time.Millisecond)
+
+	errs := configureTransport(t, ms, map[string]tftypes.Value{
+		"request_timeout": tftypes.NewValue(tftypes.String, "50ms"),
+	})
+	if !strings.Contains(errs, "Timeout") {
+		t.Fatalf("expected the slow API to time out, got: %q", errs)
+	}
+}
+
+func TestTransport_RequestTimeoutNotReached(t *testing.T) {
+	ms := transportServer(t)
+	ms.SetResponseDelay(20 * time.Millisecond)
+
+	if errs := configureTransport(t, ms, map[string]tftypes.Value{
+		"request_timeout": tftypes.NewValue(tftypes.String, "5s"),
+	}); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+}
+
+func TestTransport_ProxyURL(t *testing.T) {
+	ms := transportServer(t)
+	proxy := &forwardProxy{}
+	proxyServer := httptest.NewServer(proxy)
+	t.Cleanup(proxyServer.Close)
+
+	if errs := configureTransport(t, ms, map[string]tftypes.Value{
+		"proxy_url":      tftypes.NewValue(tftypes.String, proxyServer.URL),
+		"max_idle_conns": tftypes.NewValue(tftypes.Number, 8),
+	}); errs != "" {
+		t.Fatalf("unexpected errors: %s", errs)
+	}
+	if proxy.requestCount() == 0 {
+		t.Fatal("expected API requests to go through the proxy")
+	}
+}
+
+func TestTransport_InvalidSettings(t *testing.T) {
+	tests := map[string]struct {
+		values map[string]tftypes.Value
+		want   string
+	}{
+		"request_timeout": {
+			values: map[string]tftypes.Value{"request_timeout": tftypes.NewValue(tftypes.String, "soon")},
+			want:   "Invalid Request Timeout",
+		},
+		"operation_timeout": {
+			values: map[string]tftypes.Value{"operation_timeout": tftypes.NewValue(tftypes.String, "-1m")},
+			want:   "Invalid Operation Timeout",
+		},
+		"proxy_url": {
+			values: map[string]tftypes.Value{"proxy_url": tftypes.NewValue(tftypes.String, "ftp://proxy:21")},
+			want:   "Invalid Proxy URL",
+		},
+		"max_idle_conns": {
+			values: map[string]tftypes.Value{"max_idle_conns": tftypes.NewValue(tftypes.Number, -1)},
+			want:   "Invalid Max Idle Connections",
+		},
+	}
+
+	ms := transportServer(t)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if errs := configureTransport(t, ms, tc.values); !strings.Contains(errs, tc.want) {
+				t.Fatalf("expected %q, got: %q", tc.want, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"testing"
+
+	"terraform-provider-verity/internal/utils"
+)
+
+func TestFieldAppliesToAPIVersion(t *testing.T) {
+	utils.ModeFields["test_resources"] = map[string]utils.FieldMode{
+		"always":  utils.FieldModeBoth,
+		"added":   utils.FieldModeBoth.Since(6),
+		"removed": utils.FieldModeDatacenter.Until(4),
+		"window":  utils.FieldModeCampus.Since(3).Until(5),
+	}
+	t.Cleanup(func() { delete(utils.ModeFields, "test_resources") })
+
+	tests := []struct {
+		field   string
+		version string
+		want    bool
+	}{
+		{"always", "6.5", true},
+		{"added", "6.5", false},
+		{"added", "6.6", true},
+		{"removed", "6.4", true},
+		{"removed", "6.5", false},
+		{"window", "6.2", false},
+		{"window", "6.3", true},
+		{"window", "6.5", true},
+		{"window", "6.6", false},
+		{"unknown", "6.1", true},
+		{"added", "", true},
+		{"added", "7.0", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.field+"@"+tc.version, func(t *testing.T) {
+			if got := utils.FieldAppliesToAPIVersion("test_resources", tc.field, tc.version); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if !utils.FieldAppliesToMode("test_resources", "removed", "datacenter") || utils.FieldAppliesToMode("test_resources", "removed", "campus") {
+		t.Error("expected a version range to keep the field's mode")
+	}
+}
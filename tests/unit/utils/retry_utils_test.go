@@ -0,0 +1,27 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"terraform-provider-verity/internal/utils"
+)
+
+func TestCalculateBackoffStaysWithinMaxDelay(t *testing.T) {
+	config := utils.DefaultRetryConfig()
+
+	for _, attempt := range []int{0, 5, 40, 100, 10000} {
+		delay := utils.CalculateBackoff(attempt, config)
+		if delay <= 0 || delay > config.MaxDelay {
+			t.Errorf("attempt %d: expected a delay in (0, %s], got %s", attempt, config.MaxDelay, delay)
+		}
+	}
+
+	config.Jitter = 0
+	if delay := utils.CalculateBackoff(1000, config); delay != config.MaxDelay {
+		t.Errorf("expected a late attempt to wait max_delay, got %s", delay)
+	}
+	if delay := utils.CalculateBackoff(1, config); delay != time.Second {
+		t.Errorf("expected the second attempt to wait 1s, got %s", delay)
+	}
+}
@@ -0,0 +1,148 @@
+package utils_test
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-verity/internal/utils"
+)
+
+func TestParseOutOfServiceSwitchpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty body", body: "  ", want: nil},
+		{name: "list of names", body: `["sw2","sw1"]`, want: []string{"sw1", "sw2"}},
+		{name: "list of objects", body: `[{"name":"sw1","mos":true},{"name":"sw2"}]`, want: []string{"sw1", "sw2"}},
+		{name: "device_names list", body: `{"device_names":["sw1"]}`, want: []string{"sw1"}},
+		{name: "switchpoint keyed by name", body: `{"switchpoint":{"sw2":{"mos":true},"sw1":{"mos":true}}}`, want: []string{"sw1", "sw2"}},
+		{name: "switchpoints list of objects", body: `{"switchpoints":[{"name":"sw1"}]}`, want: []string{"sw1"}},
+		{name: "empty list", body: `{"device_names":[]}`, want: nil},
+		{name: "unknown object", body: `{"status":"ok"}`, want: nil},
+		{name: "invalid JSON", body: `{"device_names":`, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := utils.ParseOutOfServiceSwitchpoints([]byte(tc.body))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSplitConfigSections(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   map[string]string
+	}{
+		{name: "empty", config: "", want: map[string]string{}},
+		{
+			name:   "indented blocks",
+			config: "hostname sw1\ninterface Ethernet1\n   description uplink\n   no shutdown\nrouter bgp 65000\n   router-id 10.0.0.1\n",
+			want: map[string]string{
+				"hostname sw1":        "hostname sw1",
+				"interface Ethernet1": "interface Ethernet1\n   description uplink\n   no shutdown",
+				"router bgp 65000":    "router bgp 65000\n   router-id 10.0.0.1",
+			},
+		},
+		{
+			name:   "comments, separators and CRLF",
+			config: "! generated\r\n# header\r\nvlan 10\r\n\tname users\r\n!\r\n",
+			want:   map[string]string{"vlan 10": "vlan 10\n\tname users"},
+		},
+		{
+			name:   "indented lines before the first header are dropped",
+			config: "  orphan\nvlan 10\n",
+			want:   map[string]string{"vlan 10": "vlan 10"},
+		},
+		{
+			name:   "repeated headers are merged",
+			config: "vlan 10\n  name a\nvlan 10\n  state active\n",
+			want:   map[string]string{"vlan 10": "vlan 10\n  name a\nvlan 10\n  state active"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := utils.SplitConfigSections(tc.config); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseSwitchpointCurrentConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantConfig   string
+		wantSections map[string]string
+	}{
+		{
+			name:         "plain text",
+			body:         "vlan 10\n  name users\n",
+			wantConfig:   "vlan 10\n  name users\n",
+			wantSections: map[string]string{"vlan 10": "vlan 10\n  name users"},
+		},
+		{
+			name:         "JSON string",
+			body:         `"vlan 10\n  name users"`,
+			wantConfig:   "vlan 10\n  name users",
+			wantSections: map[string]string{"vlan 10": "vlan 10\n  name users"},
+		},
+		{
+			name:         "keyed by switchpoint name",
+			body:         `{"sw1":"vlan 10","sw2":"vlan 20"}`,
+			wantConfig:   "vlan 10",
+			wantSections: map[string]string{"vlan 10": "vlan 10"},
+		},
+		{
+			name:         "config key",
+			body:         `{"name":"sw1","config":"vlan 10"}`,
+			wantConfig:   "vlan 10",
+			wantSections: map[string]string{"vlan 10": "vlan 10"},
+		},
+		{
+			name:         "switchpoint envelope keyed by name",
+			body:         `{"switchpoint":{"sw1":{"config":"vlan 10"}}}`,
+			wantConfig:   "vlan 10",
+			wantSections: map[string]string{"vlan 10": "vlan 10"},
+		},
+		{
+			name:         "structured configuration",
+			body:         `{"hostname":"sw1","interfaces":{"Ethernet1":{"enable":true}}}`,
+			wantConfig:   "{\n  \"hostname\": \"sw1\",\n  \"interfaces\": {\n    \"Ethernet1\": {\n      \"enable\": true\n    }\n  }\n}",
+			wantSections: map[string]string{"hostname": "sw1", "interfaces": "{\n  \"Ethernet1\": {\n    \"enable\": true\n  }\n}"},
+		},
+		{
+			name:         "structured configuration with a single section",
+			body:         `{"interfaces":{"Ethernet1":{"enable":true}}}`,
+			wantConfig:   "{\n  \"interfaces\": {\n    \"Ethernet1\": {\n      \"enable\": true\n    }\n  }\n}",
+			wantSections: map[string]string{"interfaces": "{\n  \"Ethernet1\": {\n    \"enable\": true\n  }\n}"},
+		},
+		{
+			name:         "unexpected JSON",
+			body:         `[1,2]`,
+			wantConfig:   `[1,2]`,
+			wantSections: map[string]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, sections := utils.ParseSwitchpointCurrentConfig("sw1", []byte(tc.body))
+			if config != tc.wantConfig {
+				t.Errorf("expected config %q, got %q", tc.wantConfig, config)
+			}
+			if !reflect.DeepEqual(sections, tc.wantSections) {
+				t.Errorf("expected sections %q, got %q", tc.wantSections, sections)
+			}
+		})
+	}
+}
@@ -454,14 +454,33 @@ def generate_go_mode_map(comparisons: List[EndpointComparison]) -> str:
         "",
         "package utils",
         "",
-        "type FieldMode string",
+        "// FieldMode is the mode a field applies to, and the range of minor API versions of",
+        "// SupportedAPIMajor the field exists in. A zero MinMinor or MaxMinor leaves that end of",
+        "// the range open.",
+        "type FieldMode struct {",
+        "    Mode     string",
+        "    MinMinor int",
+        "    MaxMinor int",
+        "}",
         "",
-        "const (",
-        '    FieldModeBoth       FieldMode = "both"',
-        '    FieldModeDatacenter FieldMode = "datacenter"',
-        '    FieldModeCampus     FieldMode = "campus"',
+        "var (",
+        '    FieldModeBoth       = FieldMode{Mode: "both"}',
+        '    FieldModeDatacenter = FieldMode{Mode: "datacenter"}',
+        '    FieldModeCampus     = FieldMode{Mode: "campus"}',
         ")",
         "",
+        "// Since returns the field mode for a field added in the given minor API version.",
+        "func (m FieldMode) Since(minor int) FieldMode {",
+        "    m.MinMinor = minor",
+        "    return m",
+        "}",
+        "",
+        "// Until returns the field mode for a field removed after the given minor API version.",
+        "func (m FieldMode) Until(minor int) FieldMode {",
+        "    m.MaxMinor = minor",
+        "    return m",
+        "}",
+        "",
         "// FieldAppliesToMode checks if a field applies to the given mode.",
         "// Returns true if the field should be populated for the given mode.",
         "// If the field is not found in ModeFields, it defaults to true (applies to both modes).",
@@ -478,19 +497,30 @@ def generate_go_mode_map(comparisons: List[EndpointComparison]) -> str:
         '        return true',
         '    }',
         '',
-        '    switch fieldMode {',
-        '    case FieldModeBoth:',
+        '    switch fieldMode.Mode {',
+        '    case FieldModeBoth.Mode:',
         '        return true',
-        '    case FieldModeDatacenter:',
+        '    case FieldModeDatacenter.Mode:',
         '        return mode == "datacenter"',
-        '    case FieldModeCampus:',
+        '    case FieldModeCampus.Mode:',
         '        return mode == "campus"',
         '    default:',
         '        return true',
         '    }',
         "}",
         "",
-        "// ModeFields maps field names to their applicable mode",
+        "// FieldAppliesToVersion checks if a field exists in the given minor API version.",
+        "// If the field is not found in ModeFields, it defaults to true (exists in all versions).",
+        "func FieldAppliesToVersion(resourceType, fieldName string, minor int) bool {",
+        '    fieldMode, ok := ModeFields[resourceType][fieldName]',
+        '    if !ok {',
+        '        return true',
+        '    }',
+        '    return minor >= fieldMode.MinMinor && (fieldMode.MaxMinor == 0 || minor <= fieldMode.MaxMinor)',
+        "}",
+        "",
+        "// ModeFields maps field names to their applicable mode and API versions. Fields that do not",
+        "// exist in every supported version use Since or Until, e.g. FieldModeBoth.Since(6).",
         "var ModeFields = map[string]map[string]FieldMode{",
     ]
     